@@ -15,6 +15,10 @@ var (
 )
 
 func main() {
+	if commands.IsVetToolInvocation(os.Args[1:]) {
+		commands.ExecuteVetTool(version, commit, date)
+	}
+
 	e := commands.NewExecutor(version, commit, date)
 
 	if err := e.Execute(); err != nil {
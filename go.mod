@@ -30,7 +30,7 @@ require (
 	github.com/magiconair/properties v1.8.1 // indirect
 	github.com/matoous/godox v0.0.0-20190911065817-5d6d842e92eb
 	github.com/mattn/go-colorable v0.1.4
-	github.com/mattn/go-isatty v0.0.9 // indirect
+	github.com/mattn/go-isatty v0.0.9
 	github.com/mitchellh/go-homedir v1.1.0
 	github.com/mitchellh/go-ps v0.0.0-20190716172923-621e5597135b
 	github.com/onsi/ginkgo v1.10.2 // indirect
@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Dir returns the directory of the cache.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// AgeBucket counts cache entries last used not earlier than MaxAge ago
+// like Stats does. Zero MaxAge means any age.
+type AgeBucket struct {
+	MaxAge  time.Duration
+	Entries int
+	Outputs int
+	Size    int64
+}
+
+// Stats describes the cache contents. Ages are measured by the last use
+// time, it's updated with mtimeInterval precision.
+type Stats struct {
+	Dir     string
+	Entries int // action entries (xxxx-a files)
+	Outputs int // output entries (xxxx-d files)
+	Size    int64
+	Ages    []AgeBucket // cover all entries, ordered by MaxAge
+}
+
+var statAgeBuckets = []time.Duration{
+	time.Hour,
+	24 * time.Hour,
+	trimLimit,
+	30 * 24 * time.Hour,
+	0,
+}
+
+// entryFile is a file of an action (xxxx-a) or output (xxxx-d) entry.
+type entryFile struct {
+	path string
+	info os.FileInfo
+}
+
+func (e entryFile) isAction() bool {
+	return strings.HasSuffix(e.path, "-a")
+}
+
+// walkEntries calls f for each entry file. It doesn't update entries
+// usage times.
+func (c *Cache) walkEntries(f func(e entryFile) error) error {
+	for i := 0; i < 256; i++ {
+		subdir := filepath.Join(c.dir, fmt.Sprintf("%02x", i))
+		d, err := os.Open(subdir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		infos, err := d.Readdir(-1)
+		d.Close()
+		if err != nil {
+			return errors.Wrapf(err, "failed to read dir %s", subdir)
+		}
+
+		for _, info := range infos {
+			name := info.Name()
+			if !strings.HasSuffix(name, "-a") && !strings.HasSuffix(name, "-d") {
+				continue
+			}
+			if err := f(entryFile{path: filepath.Join(subdir, name), info: info}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Stat returns statistics of the cache contents.
+func (c *Cache) Stat() (*Stats, error) {
+	s := &Stats{Dir: c.dir}
+	for _, maxAge := range statAgeBuckets {
+		s.Ages = append(s.Ages, AgeBucket{MaxAge: maxAge})
+	}
+
+	now := c.now()
+	count := func(entries, outputs *int, size *int64, e entryFile) {
+		if e.isAction() {
+			*entries++
+		} else {
+			*outputs++
+		}
+		*size += e.info.Size()
+	}
+
+	err := c.walkEntries(func(e entryFile) error {
+		count(&s.Entries, &s.Outputs, &s.Size, e)
+
+		age := now.Sub(e.info.ModTime())
+		for i := range s.Ages {
+			b := &s.Ages[i]
+			if b.MaxAge == 0 || age <= b.MaxAge {
+				count(&b.Entries, &b.Outputs, &b.Size, e)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Clean removes all cache entries.
+func (c *Cache) Clean() error {
+	err := c.walkEntries(func(e entryFile) error {
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(filepath.Join(c.dir, "trim.txt")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// DefaultTrimAge is the minimal age of entries removed by Trim.
+const DefaultTrimAge = trimLimit + mtimeInterval
+
+// CorruptEntry is a cache entry that will never be returned by the cache.
+type CorruptEntry struct {
+	Path   string
+	Reason string
+}
+
+// Verify checks integrity of all cache entries: the format of action entries,
+// presence of their outputs and the hashes of outputs.
+func (c *Cache) Verify() ([]CorruptEntry, error) {
+	var ret []CorruptEntry
+	err := c.walkEntries(func(e entryFile) error {
+		var reason string
+		if e.isAction() {
+			reason = c.verifyActionEntry(e)
+		} else {
+			reason = verifyOutputEntry(e)
+		}
+		if reason != "" {
+			ret = append(ret, CorruptEntry{Path: e.path, Reason: reason})
+		}
+		return nil
+	})
+
+	return ret, err
+}
+
+func (c *Cache) verifyActionEntry(e entryFile) string {
+	var id ActionID
+	name := strings.TrimSuffix(filepath.Base(e.path), "-a")
+	if len(name) != hexSize {
+		return "invalid file name"
+	}
+	if _, err := hex.Decode(id[:], []byte(name)); err != nil {
+		return "invalid file name"
+	}
+
+	entry, err := c.readIndexEntry(id)
+	if err != nil {
+		return "invalid action entry"
+	}
+
+	outInfo, err := os.Stat(c.fileName(entry.OutputID, "d"))
+	if err != nil {
+		return fmt.Sprintf("no output %x", entry.OutputID)
+	}
+	if outInfo.Size() != entry.Size {
+		return fmt.Sprintf("output %x has size %d, expected %d", entry.OutputID, outInfo.Size(), entry.Size)
+	}
+
+	return ""
+}
+
+func verifyOutputEntry(e entryFile) string {
+	var out OutputID
+	name := strings.TrimSuffix(filepath.Base(e.path), "-d")
+	if len(name) != hexSize {
+		return "invalid file name"
+	}
+	if _, err := hex.Decode(out[:], []byte(name)); err != nil {
+		return "invalid file name"
+	}
+
+	f, err := os.Open(e.path)
+	if err != nil {
+		return fmt.Sprintf("can't open: %s", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return fmt.Sprintf("can't read: %s", err)
+	}
+	var sum OutputID
+	h.Sum(sum[:0])
+	if sum != out {
+		return "content hash mismatch"
+	}
+
+	return ""
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestStatTrimVerify(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cachetest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := openTempCache(t, dir, "c")
+	now := time.Now()
+	c.now = func() time.Time { return now.Add(-10 * 24 * time.Hour) }
+	if err = c.PutBytes(ActionID(dummyID(1)), []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+	c.now = func() time.Time { return now }
+	if err = c.PutBytes(ActionID(dummyID(2)), []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := c.Stat()
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if stats.Entries != 2 || stats.Outputs != 2 {
+		t.Fatalf("Stat: %d entries and %d outputs, want 2 and 2", stats.Entries, stats.Outputs)
+	}
+	if b := stats.Ages[0]; b.Entries != 1 || b.Outputs != 1 {
+		t.Fatalf("Stat: %d recently used entries and %d outputs, want 1 and 1", b.Entries, b.Outputs)
+	}
+	if b := stats.Ages[3]; b.Entries != 1 || b.Outputs != 1 {
+		t.Fatalf("Stat: %d entries and %d outputs used %s ago, want 1 and 1", b.Entries, b.Outputs, b.MaxAge)
+	}
+
+	if corrupted, err := c.Verify(); err != nil || len(corrupted) != 0 {
+		t.Fatalf("Verify: %v, %v, want no corrupted entries", corrupted, err)
+	}
+
+	if removed, _ := c.TrimOlderThan(DefaultTrimAge); removed != 2 {
+		t.Fatalf("TrimOlderThan: removed %d, want 2", removed)
+	}
+	if _, _, err = c.GetBytes(ActionID(dummyID(1))); !IsErrMissing(err) {
+		t.Fatalf("GetBytes of trimmed entry: %v, want missing", err)
+	}
+	data, _, err := c.GetBytes(ActionID(dummyID(2)))
+	if err != nil || string(data) != "new" {
+		t.Fatalf("GetBytes = %q, %v, want %q", data, err, "new")
+	}
+
+	entry, err := c.Get(ActionID(dummyID(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(c.OutputFile(entry.OutputID), []byte("bad"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	corrupted, err := c.Verify()
+	if err != nil || len(corrupted) != 1 || corrupted[0].Path != c.OutputFile(entry.OutputID) {
+		t.Fatalf("Verify: %v, %v, want corrupted output", corrupted, err)
+	}
+
+	if err = c.Clean(); err != nil {
+		t.Fatalf("Clean: %v", err)
+	}
+	if stats, err = c.Stat(); err != nil || stats.Entries+stats.Outputs != 0 {
+		t.Fatalf("Stat after Clean: %+v, %v, want no entries", stats, err)
+	}
+}
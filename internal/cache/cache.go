@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golangci/golangci-lint/internal/renameio"
@@ -37,6 +38,10 @@ type OutputID [HashSize]byte
 type Cache struct {
 	dir string
 	now func() time.Time
+
+	remoteMu  sync.Mutex
+	remote    Backend
+	remoteErr error
 }
 
 // Open opens and returns the cache in the given directory.
@@ -141,6 +146,18 @@ type Entry struct {
 
 // get is Get but does not respect verify mode, so that Put can use it.
 func (c *Cache) get(id ActionID) (Entry, error) {
+	entry, err := c.readIndexEntry(id)
+	if err != nil {
+		return entry, err
+	}
+
+	c.used(c.fileName(id, "a"))
+
+	return entry, nil
+}
+
+// readIndexEntry is get but does not update the entry usage time.
+func (c *Cache) readIndexEntry(id ActionID) (Entry, error) {
 	missing := func() (Entry, error) {
 		return Entry{}, errMissing
 	}
@@ -185,8 +202,6 @@ func (c *Cache) get(id ActionID) (Entry, error) {
 		return missing()
 	}
 
-	c.used(c.fileName(id, "a"))
-
 	return Entry{buf, size, time.Unix(0, tm)}, nil
 }
 
@@ -211,6 +226,9 @@ func (c *Cache) GetFile(id ActionID) (file string, entry Entry, err error) {
 func (c *Cache) GetBytes(id ActionID) ([]byte, Entry, error) {
 	entry, err := c.Get(id)
 	if err != nil {
+		if IsErrMissing(err) && !verify {
+			return c.getRemoteBytes(id)
+		}
 		return nil, entry, err
 	}
 	data, _ := ioutil.ReadFile(c.OutputFile(entry.OutputID))
@@ -275,22 +293,36 @@ func (c *Cache) Trim() {
 		return
 	}
 
-	// Trim each of the 256 subdirectories.
 	// We subtract an additional mtimeInterval
 	// to account for the imprecision of our "last used" mtimes.
-	cutoff := now.Add(-trimLimit - mtimeInterval)
+	c.TrimOlderThan(trimLimit + mtimeInterval)
+}
+
+// TrimOlderThan removes entries that were not used for maxAge regardless
+// of the time of the last trim. It returns the count and size of removed files.
+func (c *Cache) TrimOlderThan(maxAge time.Duration) (int, int64) {
+	now := c.now()
+
+	// Trim each of the 256 subdirectories.
+	cutoff := now.Add(-maxAge)
+	var removed int
+	var removedSize int64
 	for i := 0; i < 256; i++ {
 		subdir := filepath.Join(c.dir, fmt.Sprintf("%02x", i))
-		c.trimSubdir(subdir, cutoff)
+		n, size := c.trimSubdir(subdir, cutoff)
+		removed += n
+		removedSize += size
 	}
 
 	// Ignore errors from here: if we don't write the complete timestamp, the
 	// cache will appear older than it is, and we'll trim it again next time.
 	renameio.WriteFile(filepath.Join(c.dir, "trim.txt"), []byte(fmt.Sprintf("%d", now.Unix())), 0666)
+
+	return removed, removedSize
 }
 
-// trimSubdir trims a single cache subdirectory.
-func (c *Cache) trimSubdir(subdir string, cutoff time.Time) {
+// trimSubdir trims a single cache subdirectory and returns the count and size of removed files.
+func (c *Cache) trimSubdir(subdir string, cutoff time.Time) (removed int, removedSize int64) {
 	// Read all directory entries from subdir before removing
 	// any files, in case removing files invalidates the file offset
 	// in the directory scan. Also, ignore error from f.Readdirnames,
@@ -298,7 +330,7 @@ func (c *Cache) trimSubdir(subdir string, cutoff time.Time) {
 	// want to process any entries found before the error.
 	f, err := os.Open(subdir)
 	if err != nil {
-		return
+		return 0, 0
 	}
 	names, _ := f.Readdirnames(-1)
 	f.Close()
@@ -310,10 +342,13 @@ func (c *Cache) trimSubdir(subdir string, cutoff time.Time) {
 		}
 		entry := filepath.Join(subdir, name)
 		info, err := os.Stat(entry)
-		if err == nil && info.ModTime().Before(cutoff) {
-			os.Remove(entry)
+		if err == nil && info.ModTime().Before(cutoff) && os.Remove(entry) == nil {
+			removed++
+			removedSize += info.Size()
 		}
 	}
+
+	return removed, removedSize
 }
 
 // putIndexEntry adds an entry to the cache recording that executing the action
@@ -411,8 +446,10 @@ func (c *Cache) put(id ActionID, file io.ReadSeeker, allowVerify bool) (OutputID
 
 // PutBytes stores the given bytes in the cache as the output for the action ID.
 func (c *Cache) PutBytes(id ActionID, data []byte) error {
-	_, _, err := c.Put(id, bytes.NewReader(data))
-	return err
+	if _, _, err := c.Put(id, bytes.NewReader(data)); err != nil {
+		return err
+	}
+	return c.putRemoteBytes(id, data)
 }
 
 // copyFile copies file into the cache, expecting it to have the given
@@ -7,7 +7,6 @@ package cache
 import (
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
 	"sync"
@@ -16,12 +15,13 @@ import (
 // Default returns the default cache to use.
 func Default() (*Cache, error) {
 	defaultOnce.Do(initDefaultCache)
-	return defaultCache, defaultDirErr
+	return defaultCache, defaultCacheErr
 }
 
 var (
-	defaultOnce  sync.Once
-	defaultCache *Cache
+	defaultOnce     sync.Once
+	defaultCache    *Cache
+	defaultCacheErr error
 )
 
 // cacheREADME is a message stored in a README in the cache directory.
@@ -34,8 +34,13 @@ const cacheREADME = `This directory holds cached build artifacts from golangci-l
 // the first time Default is called.
 func initDefaultCache() {
 	dir := DefaultDir()
+	if defaultDirErr != nil {
+		defaultCacheErr = defaultDirErr
+		return
+	}
 	if err := os.MkdirAll(dir, 0777); err != nil {
-		log.Fatalf("failed to initialize build cache at %s: %s\n", dir, err)
+		defaultCacheErr = fmt.Errorf("failed to initialize build cache at %s: %s", dir, err)
+		return
 	}
 	if _, err := os.Stat(filepath.Join(dir, "README")); err != nil {
 		// Best effort.
@@ -44,7 +49,17 @@ func initDefaultCache() {
 
 	c, err := Open(dir)
 	if err != nil {
-		log.Fatalf("failed to initialize build cache at %s: %s\n", dir, err)
+		defaultCacheErr = fmt.Errorf("failed to initialize build cache at %s: %s", dir, err)
+		return
+	}
+
+	if remote := os.Getenv("GOLANGCI_LINT_CACHE_REMOTE"); remote != "" {
+		b, err := NewBackend(remote)
+		if err != nil {
+			defaultCacheErr = fmt.Errorf("failed to initialize remote cache GOLANGCI_LINT_CACHE_REMOTE: %s", err)
+			return
+		}
+		c.SetRemote(b)
 	}
 	defaultCache = c
 }
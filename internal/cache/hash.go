@@ -165,6 +165,14 @@ func FileHash(file string) ([HashSize]byte, error) {
 	return out, nil
 }
 
+// ForgetFileHash drops the cached hash of the file: FileHash will read
+// the file again. Long-running processes call it for changed files.
+func ForgetFileHash(file string) {
+	hashFileCache.Lock()
+	delete(hashFileCache.m, file)
+	hashFileCache.Unlock()
+}
+
 // SetFileHash sets the hash returned by FileHash for file.
 func SetFileHash(file string, sum [HashSize]byte) {
 	hashFileCache.Lock()
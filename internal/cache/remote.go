@@ -0,0 +1,269 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/internal/renameio"
+)
+
+// A Backend is a remote storage of cache entries shared between machines,
+// e.g. between CI runs and developer laptops. The local cache directory
+// is always used first, the backend is queried only on local misses.
+//
+// Implementations must be safe for concurrent use.
+type Backend interface {
+	// Get returns data stored by the action ID or an error satisfying IsErrMissing.
+	Get(id ActionID) ([]byte, error)
+	Put(id ActionID, data []byte) error
+}
+
+// Remote entry is "golangci-lint-cache v1 <hex sha256 of data>\n<data>":
+// the hash protects against truncated uploads and corrupted storage.
+const remoteEntryPrefix = "golangci-lint-cache v1 "
+
+// maxRemoteEntrySize limits downloads from a misbehaving server: cached
+// facts and issues of a package are much smaller.
+const maxRemoteEntrySize = 128 << 20
+
+func encodeRemoteEntry(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	var buf bytes.Buffer
+	buf.Grow(len(remoteEntryPrefix) + hexSize + 1 + len(data))
+	buf.WriteString(remoteEntryPrefix)
+	buf.WriteString(hex.EncodeToString(sum[:]))
+	buf.WriteByte('\n')
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+func decodeRemoteEntry(entry []byte) ([]byte, error) {
+	headerLen := len(remoteEntryPrefix) + hexSize + 1
+	if len(entry) < headerLen || !bytes.HasPrefix(entry, []byte(remoteEntryPrefix)) || entry[headerLen-1] != '\n' {
+		return nil, errors.New("invalid remote cache entry header")
+	}
+
+	var sum OutputID
+	if _, err := hex.Decode(sum[:], entry[len(remoteEntryPrefix):headerLen-1]); err != nil {
+		return nil, errors.Wrap(err, "invalid remote cache entry hash")
+	}
+
+	data := entry[headerLen:]
+	if sha256.Sum256(data) != sum {
+		return nil, errors.New("remote cache entry hash mismatch")
+	}
+
+	return data, nil
+}
+
+// DirBackend stores entries in a directory shared between machines,
+// e.g. mounted by NFS or restored by CI cache steps. Entries are written
+// to temporary files and renamed, so concurrent writers never expose
+// partially written entries and don't need file locks.
+type DirBackend struct {
+	dir string
+}
+
+func NewDirBackend(dir string) (*DirBackend, error) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, errors.Wrapf(err, "failed to create shared cache dir %s", dir)
+	}
+
+	return &DirBackend{dir: dir}, nil
+}
+
+func (b DirBackend) fileName(id ActionID) string {
+	return filepath.Join(b.dir, fmt.Sprintf("%02x", id[0]), fmt.Sprintf("%x", id))
+}
+
+func (b DirBackend) Get(id ActionID) ([]byte, error) {
+	data, err := renameio.ReadFile(b.fileName(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errMissing
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (b DirBackend) Put(id ActionID, data []byte) error {
+	name := b.fileName(id)
+	if err := os.MkdirAll(filepath.Dir(name), 0777); err != nil {
+		return err
+	}
+
+	return renameio.WriteFile(name, data, 0666)
+}
+
+// HTTPBackend stores entries on an HTTP server: an entry is downloaded by
+// "GET <base url>/<hex action id>" and uploaded by "PUT <base url>/<hex action id>".
+// The server must respond with 404 to GET of an unknown entry.
+type HTTPBackend struct {
+	baseURL string
+	client  *http.Client
+}
+
+const httpBackendTimeout = 10 * time.Second
+
+func NewHTTPBackend(baseURL string) *HTTPBackend {
+	return &HTTPBackend{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: httpBackendTimeout},
+	}
+}
+
+func (b HTTPBackend) url(id ActionID) string {
+	return fmt.Sprintf("%s/%x", b.baseURL, id)
+}
+
+func (b HTTPBackend) Get(id ActionID) ([]byte, error) {
+	resp, err := b.client.Get(b.url(id))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRemoteEntrySize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxRemoteEntrySize {
+			return nil, fmt.Errorf("GET %s: entry is larger than %d bytes", b.url(id), maxRemoteEntrySize)
+		}
+		return data, nil
+	case http.StatusNotFound:
+		return nil, errMissing
+	default:
+		return nil, fmt.Errorf("GET %s: unexpected status %s", b.url(id), resp.Status)
+	}
+}
+
+func (b HTTPBackend) Put(id ActionID, data []byte) error {
+	req, err := http.NewRequest(http.MethodPut, b.url(id), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body) //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("PUT %s: unexpected status %s", b.url(id), resp.Status)
+	}
+
+	return nil
+}
+
+// NewBackend makes a backend by its location: an http(s) URL or
+// an absolute path (optionally with file:// scheme) to a shared directory.
+func NewBackend(location string) (Backend, error) {
+	switch {
+	case strings.HasPrefix(location, "http://"), strings.HasPrefix(location, "https://"):
+		return NewHTTPBackend(location), nil
+	case strings.HasPrefix(location, "file://"):
+		location = strings.TrimPrefix(location, "file://")
+	}
+
+	if !filepath.IsAbs(location) {
+		return nil, fmt.Errorf("remote cache location %q is neither an http(s) URL nor an absolute path", location)
+	}
+
+	return NewDirBackend(location)
+}
+
+// SetRemote sets a backend to share cache entries with other machines.
+func (c *Cache) SetRemote(b Backend) {
+	c.remoteMu.Lock()
+	defer c.remoteMu.Unlock()
+	c.remote = b
+	c.remoteErr = nil
+}
+
+func (c *Cache) getRemote() Backend {
+	c.remoteMu.Lock()
+	defer c.remoteMu.Unlock()
+	return c.remote
+}
+
+// disableRemote turns the backend off after the first failure: an unavailable
+// backend shouldn't slow down every cache access or flood the log.
+// Only the first failure is reported to the caller.
+func (c *Cache) disableRemote(err error) error {
+	c.remoteMu.Lock()
+	defer c.remoteMu.Unlock()
+	if c.remoteErr != nil || c.remote == nil {
+		return nil
+	}
+	c.remote = nil
+	c.remoteErr = err
+	return errors.Wrap(err, "remote cache failed, it's disabled for this run")
+}
+
+func (c *Cache) getRemoteBytes(id ActionID) ([]byte, Entry, error) {
+	remote := c.getRemote()
+	if remote == nil {
+		return nil, Entry{}, errMissing
+	}
+
+	remoteEntry, err := remote.Get(id)
+	if err != nil {
+		if IsErrMissing(err) {
+			return nil, Entry{}, errMissing
+		}
+		if err = c.disableRemote(err); err != nil {
+			return nil, Entry{}, err
+		}
+		return nil, Entry{}, errMissing
+	}
+
+	data, err := decodeRemoteEntry(remoteEntry)
+	if err != nil {
+		return nil, Entry{}, errMissing // will be overwritten by the next put
+	}
+
+	// Save to the local cache to not download it again.
+	out, size, err := c.Put(id, bytes.NewReader(data))
+	if err != nil {
+		return nil, Entry{}, err
+	}
+
+	return data, Entry{OutputID: out, Size: size, Time: c.now()}, nil
+}
+
+func (c *Cache) putRemoteBytes(id ActionID, data []byte) error {
+	remote := c.getRemote()
+	if remote == nil {
+		return nil
+	}
+
+	entry := encodeRemoteEntry(data)
+	if len(entry) > maxRemoteEntrySize {
+		return nil // other machines wouldn't download it
+	}
+
+	if err := remote.Put(id, entry); err != nil {
+		return c.disableRemote(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package cache
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// memoryServer is a stand-in of a remote cache server.
+type memoryServer struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (s *memoryServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodGet:
+		data, ok := s.entries[key]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	case http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.entries[key] = data
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func openTempCache(t *testing.T, dir, name string) *Cache {
+	cdir := filepath.Join(dir, name)
+	if err := os.Mkdir(cdir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Open(cdir)
+	if err != nil {
+		t.Fatalf("Open(%s): %v", name, err)
+	}
+	return c
+}
+
+func testRemoteSharing(t *testing.T, newBackend func(dir string) Backend) {
+	dir, err := ioutil.TempDir("", "cachetest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	b := newBackend(dir)
+	c1 := openTempCache(t, dir, "c1")
+	c1.SetRemote(b)
+	c2 := openTempCache(t, dir, "c2")
+	c2.SetRemote(b)
+
+	id := ActionID(dummyID(1))
+	if err = c1.PutBytes(id, []byte("facts")); err != nil {
+		t.Fatalf("PutBytes: %v", err)
+	}
+
+	data, entry, err := c2.GetBytes(id)
+	if err != nil {
+		t.Fatalf("GetBytes from remote: %v", err)
+	}
+	if string(data) != "facts" || entry.Size != int64(len("facts")) {
+		t.Fatalf("GetBytes from remote = %q, %d, want %q, %d", data, entry.Size, "facts", len("facts"))
+	}
+
+	// The entry must be saved locally.
+	c2.SetRemote(nil)
+	if data, _, err = c2.GetBytes(id); err != nil || string(data) != "facts" {
+		t.Fatalf("GetBytes from local = %q, %v, want %q", data, err, "facts")
+	}
+
+	if _, _, err = c1.GetBytes(ActionID(dummyID(2))); !IsErrMissing(err) {
+		t.Fatalf("GetBytes of unknown entry: %v, want missing", err)
+	}
+}
+
+func TestHTTPBackend(t *testing.T) {
+	srv := httptest.NewServer(&memoryServer{entries: map[string][]byte{}})
+	defer srv.Close()
+
+	testRemoteSharing(t, func(string) Backend {
+		return NewHTTPBackend(srv.URL + "/")
+	})
+}
+
+func TestDirBackend(t *testing.T) {
+	testRemoteSharing(t, func(dir string) Backend {
+		b, err := NewDirBackend(filepath.Join(dir, "shared"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	})
+}
+
+func TestRemoteCorruptedEntry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cachetest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	b, err := NewDirBackend(filepath.Join(dir, "shared"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := ActionID(dummyID(3))
+	entry := encodeRemoteEntry([]byte("facts"))
+	if err = b.Put(id, entry[:len(entry)-1]); err != nil { // truncated upload
+		t.Fatal(err)
+	}
+
+	c := openTempCache(t, dir, "c")
+	c.SetRemote(b)
+	if _, _, err = c.GetBytes(id); !IsErrMissing(err) {
+		t.Fatalf("GetBytes of corrupted entry: %v, want missing", err)
+	}
+}
+
+func TestRemoteUnavailable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cachetest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := openTempCache(t, dir, "c")
+	c.SetRemote(NewHTTPBackend(srv.URL))
+
+	if err = c.PutBytes(ActionID(dummyID(4)), []byte("facts")); err == nil {
+		t.Fatal("PutBytes to unavailable remote succeeded, want the error reported once")
+	}
+	if err = c.PutBytes(ActionID(dummyID(5)), []byte("facts")); err != nil {
+		t.Fatalf("PutBytes after remote was disabled: %v", err)
+	}
+	if data, _, err := c.GetBytes(ActionID(dummyID(4))); err != nil || string(data) != "facts" {
+		t.Fatalf("GetBytes from local = %q, %v, want %q", data, err, "facts")
+	}
+}
+
+func TestNewBackend(t *testing.T) {
+	if _, err := NewBackend("relative/path"); err == nil {
+		t.Fatal("NewBackend of relative path succeeded, want failure")
+	}
+	if b, err := NewBackend("https://cache.example.com"); err != nil {
+		t.Fatalf("NewBackend of URL: %v", err)
+	} else if _, ok := b.(*HTTPBackend); !ok {
+		t.Fatalf("NewBackend of URL = %T, want *HTTPBackend", b)
+	}
+}
+
+func TestHTTPBackendTooLargeEntry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(make([]byte, maxRemoteEntrySize+1))
+	}))
+	defer srv.Close()
+
+	b := NewHTTPBackend(srv.URL)
+	if _, err := b.Get(ActionID(dummyID(6))); err == nil || IsErrMissing(err) {
+		t.Fatalf("Get of too large entry: %v, want an error", err)
+	}
+}
@@ -132,6 +132,15 @@ func (c *Cache) pkgActionID(pkg *packages.Package) (cache.ActionID, error) {
 	return key.Sum(), nil
 }
 
+// ForgetPackages drops hashes of packages loaded before: long-running servers
+// load packages again for every analysis.
+func (c *Cache) ForgetPackages() {
+	c.pkgHashes.Range(func(key, _ interface{}) bool {
+		c.pkgHashes.Delete(key)
+		return true
+	})
+}
+
 // packageHash computes a package's hash. The hash is based on all Go
 // files that make up the package, as well as the hashes of imported
 // packages.
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/internal/cache"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func (e *Executor) initCache() {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Cache control and information",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				e.log.Fatalf("Usage: golangci-lint cache")
+			}
+			if err := cmd.Help(); err != nil {
+				e.log.Fatalf("Can't run help: %s", err)
+			}
+		},
+	}
+	e.rootCmd.AddCommand(cacheCmd)
+
+	cacheCmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show cache location, size, entries count and ages",
+		Run:   e.executeCacheStatus,
+	})
+	cacheCmd.AddCommand(&cobra.Command{
+		Use:   "clean",
+		Short: "Remove all cache entries",
+		Run:   e.executeCacheClean,
+	})
+
+	trimCmd := &cobra.Command{
+		Use:   "trim",
+		Short: "Remove cache entries not used for some time",
+		Run:   e.executeCacheTrim,
+	}
+	trimCmd.Flags().DurationVar(&e.cacheTrimOlderThan, "older-than", cache.DefaultTrimAge,
+		wh("Remove entries not used for this duration"))
+	cacheCmd.AddCommand(trimCmd)
+
+	cacheCmd.AddCommand(&cobra.Command{
+		Use:   "verify",
+		Short: "Check integrity of cache entries",
+		Run:   e.executeCacheVerify,
+	})
+}
+
+func (e *Executor) openCache(usage string, args []string) *cache.Cache {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint cache %s", usage)
+	}
+
+	c, err := cache.Default()
+	if err != nil {
+		e.log.Fatalf("Can't open cache: %s", err)
+	}
+	return c
+}
+
+func formatAge(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	if d%time.Hour == 0 {
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return d.String()
+}
+
+func (e *Executor) executeCacheStatus(_ *cobra.Command, args []string) {
+	c := e.openCache("status", args)
+	stats, err := c.Stat()
+	if err != nil {
+		e.log.Fatalf("Can't get cache stats: %s", err)
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Dir: %s\n", stats.Dir)
+	fmt.Fprintf(logutils.StdOut, "Size: %s\n", fsutils.PrettifyBytesCount(int(stats.Size)))
+	fmt.Fprintf(logutils.StdOut, "Entries: %d (outputs: %d)\n", stats.Entries, stats.Outputs)
+	fmt.Fprintln(logutils.StdOut, "Last used:")
+
+	var prevMaxAge time.Duration
+	for _, b := range stats.Ages {
+		var ageRange string
+		switch {
+		case b.MaxAge == 0:
+			ageRange = fmt.Sprintf("more than %s ago", formatAge(prevMaxAge))
+		case prevMaxAge == 0:
+			ageRange = fmt.Sprintf("less than %s ago", formatAge(b.MaxAge))
+		default:
+			ageRange = fmt.Sprintf("%s-%s ago", formatAge(prevMaxAge), formatAge(b.MaxAge))
+		}
+		prevMaxAge = b.MaxAge
+
+		fmt.Fprintf(logutils.StdOut, "  %-20s %6d entries (outputs: %d), %s\n", ageRange+":", b.Entries, b.Outputs,
+			fsutils.PrettifyBytesCount(int(b.Size)))
+	}
+}
+
+func (e *Executor) executeCacheClean(_ *cobra.Command, args []string) {
+	c := e.openCache("clean", args)
+	if err := c.Clean(); err != nil {
+		e.log.Fatalf("Can't clean cache %s: %s", c.Dir(), err)
+	}
+}
+
+func (e *Executor) executeCacheTrim(_ *cobra.Command, args []string) {
+	c := e.openCache("trim", args)
+	removed, removedSize := c.TrimOlderThan(e.cacheTrimOlderThan)
+	fmt.Fprintf(logutils.StdOut, "Removed %d files of total size %s\n",
+		removed, fsutils.PrettifyBytesCount(int(removedSize)))
+}
+
+func (e *Executor) executeCacheVerify(_ *cobra.Command, args []string) {
+	c := e.openCache("verify", args)
+	corrupted, err := c.Verify()
+	if err != nil {
+		e.log.Fatalf("Can't verify cache %s: %s", c.Dir(), err)
+	}
+
+	for _, ce := range corrupted {
+		fmt.Fprintf(logutils.StdOut, "%s: %s\n", ce.Path, ce.Reason)
+	}
+	if len(corrupted) != 0 {
+		e.log.Errorf("Found %d corrupted cache files, run `golangci-lint cache clean` to remove all entries",
+			len(corrupted))
+		e.exitCode = exitcodes.Failure
+	}
+}
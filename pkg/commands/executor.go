@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -39,8 +41,12 @@ type Executor struct {
 	pkgCache          *pkgcache.Cache
 	debugf            logutils.DebugFunc
 	sw                *timeutils.Stopwatch
+	profiler          *timeutils.Profiler
 
 	loadGuard *load.Guard
+
+	cacheTrimOlderThan time.Duration
+	suppressionsJSON   bool
 }
 
 func NewExecutor(version, commit, date string) *Executor {
@@ -56,6 +62,22 @@ func NewExecutor(version, commit, date string) *Executor {
 	e.debugf("Starting execution...")
 	e.log = report.NewLogWrapper(logutils.NewStderrLog(""), &e.reportData)
 
+	// init of commands must be done before config file reading because
+	// init sets config with the default values of flags
+	e.initRoot()
+	e.initRun()
+	e.initHelp()
+	e.initLinters()
+	e.initConfig()
+	e.initCompletion()
+	e.initCache()
+	e.initServe()
+	e.initLSP()
+	e.initMerge()
+	e.initReport()
+	e.initSuppressions()
+	e.initGenerated()
+
 	// to setup log level early we need to parse config from command line extra time to
 	// find `-v` option
 	commandLineCfg, err := e.getConfigForCommandLine()
@@ -77,15 +99,6 @@ func NewExecutor(version, commit, date string) *Executor {
 		}
 	}
 
-	// init of commands must be done before config file reading because
-	// init sets config with the default values of flags
-	e.initRoot()
-	e.initRun()
-	e.initHelp()
-	e.initLinters()
-	e.initConfig()
-	e.initCompletion()
-
 	// init e.cfg by values from config: flags parse will see these values
 	// like the default ones. It will overwrite them only if the same option
 	// is found in command-line: it's ok, command-line has higher priority.
@@ -118,8 +131,9 @@ func NewExecutor(version, commit, date string) *Executor {
 		e.log.Fatalf("Failed to build packages cache: %s", err)
 	}
 	e.loadGuard = load.NewGuard()
+	e.profiler = timeutils.NewProfiler()
 	e.contextLoader = lint.NewContextLoader(e.cfg, e.log.Child("loader"), e.goenv,
-		e.lineCache, e.fileCache, e.pkgCache, e.loadGuard)
+		e.lineCache, e.fileCache, e.pkgCache, e.loadGuard, e.profiler)
 	e.debugf("Initialized executor")
 	return e
 }
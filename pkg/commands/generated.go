@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+func (e *Executor) initGenerated() {
+	generatedCmd := &cobra.Command{
+		Use:   "generated [packages]",
+		Short: "List files treated as generated and why: issues in them aren't reported",
+		Run:   e.executeGenerated,
+	}
+	e.rootCmd.AddCommand(generatedCmd)
+	e.initRunConfiguration(generatedCmd)
+}
+
+func (e *Executor) executeGenerated(_ *cobra.Command, args []string) {
+	e.cfg.Run.Args = args
+	if err := e.runGenerated(context.Background()); err != nil {
+		e.log.Errorf("Generated files listing error: %s", err)
+		if exitErr, ok := err.(*exitcodes.ExitError); ok {
+			e.exitCode = exitErr.Code
+			return
+		}
+		e.exitCode = exitcodes.Failure
+	}
+}
+
+func (e *Executor) runGenerated(ctx context.Context) error {
+	detector := processors.NewGeneratedDetector(&e.cfg.Issues.Generated)
+	if detector.IsDisabled() {
+		e.log.Warnf("Detection of generated files is disabled by the config")
+		return nil
+	}
+
+	pkgs, astCache, err := e.contextLoader.LoadASTCache(ctx)
+	if err != nil {
+		return err
+	}
+
+	seenFiles := map[string]bool{}
+	for _, pkg := range pkgs {
+		for _, path := range pkg.GoFiles {
+			f := astCache.Get(path)
+			if f == nil || f.Err != nil || seenFiles[f.Name] {
+				continue
+			}
+			seenFiles[f.Name] = true
+
+			reason := detector.Detect(f.Name, f.F, f.Fset)
+			if reason == "" {
+				continue
+			}
+
+			relPath, err := fsutils.ShortestRelPath(f.Name, "")
+			if err != nil {
+				relPath = f.Name
+			}
+			fmt.Fprintf(logutils.StdOut, "%s: %s\n", relPath, reason)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/lsp"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func (e *Executor) initLSP() {
+	lspCmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Run a Language Server Protocol server over stdio publishing issues as diagnostics",
+		Run:   e.executeLSP,
+	}
+	e.rootCmd.AddCommand(lspCmd)
+	e.initRunConfiguration(lspCmd)
+}
+
+func (e *Executor) executeLSP(_ *cobra.Command, args []string) {
+	if len(args) != 0 {
+		e.log.Fatalf("Usage: golangci-lint lsp")
+	}
+
+	ctx := context.Background()
+	e.prepareIncrementalAnalysis(ctx)
+
+	// Stdout is used by the protocol: anything else printed to it would break it.
+	// logutils.StdOut and color.Output captured os.Stdout at init.
+	out := os.Stdout
+	os.Stdout = os.Stderr
+	logutils.StdOut = logutils.StdErr
+	color.Output = logutils.StdErr
+
+	srv := lsp.NewServer(os.Stdin, out, lspLinter{e: e}, e.log.Child("lsp"))
+	if err := srv.Serve(ctx); err != nil {
+		e.log.Errorf("LSP server failed: %s", err)
+		e.exitCode = exitcodes.Failure
+	}
+}
+
+type lspLinter struct {
+	e *Executor
+}
+
+func (l lspLinter) Lint(ctx context.Context, dirs []string) ([]result.Issue, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get working dir")
+	}
+
+	var args []string
+	var changedFiles []string
+	for _, dir := range dirs {
+		// Files of directories to analyze were saved: they could change.
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't list Go files of %s", dir)
+		}
+		changedFiles = append(changedFiles, files...)
+
+		// Relative paths keep issues paths relative as in the run command.
+		if rel, err := filepath.Rel(wd, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			dir = "." + string(filepath.Separator) + rel
+		}
+		args = append(args, dir)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.e.cfg.Run.Deadline)
+	defer cancel()
+	l.e.forgetChangedFiles(changedFiles)
+	return l.e.analyzeIncrementally(ctx, args)
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func (e *Executor) initMerge() {
+	mergeCmd := &cobra.Command{
+		Use:   "merge report.json...",
+		Short: "Merge JSON reports of sharded runs ('golangci-lint run --shard') and print issues",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   e.executeMerge,
+	}
+	e.rootCmd.AddCommand(mergeCmd)
+	e.initRunConfiguration(mergeCmd)
+}
+
+func (e *Executor) executeMerge(_ *cobra.Command, args []string) {
+	ctx := context.Background()
+	if err := e.runMerge(ctx, args); err != nil {
+		e.log.Errorf("Merging error: %s", err)
+		e.exitCode = exitcodes.Failure
+		return
+	}
+
+	e.setupExitCode(ctx)
+}
+
+// runMerge applies processors skipped by sharded runs to issues of all shards
+// and prints them in the configured format.
+func (e *Executor) runMerge(ctx context.Context, reportPaths []string) error {
+	var issues []result.Issue
+	for _, path := range reportPaths {
+		res, err := readJSONReport(path)
+		if err != nil {
+			return err
+		}
+
+		issues = append(issues, res.Issues...)
+		if res.Report != nil {
+			mergeReportData(&e.reportData, res.Report)
+		}
+	}
+
+	// Issues limits keep first issues: make them independent of the order of reports.
+	sortMergedIssues(issues)
+
+	for _, p := range lint.NewCrossShardProcessors(e.cfg, e.log) {
+		var err error
+		if issues, err = p.Process(issues); err != nil {
+			return errors.Wrapf(err, "can't process issues by %s processor", p.Name())
+		}
+		p.Finish()
+	}
+
+	if len(withoutSuppressedIssues(issues)) != 0 {
+		e.exitCode = e.cfg.Run.ExitCodeIfIssuesFound
+	}
+	return e.printIssues(ctx, issues)
+}
+
+func sortMergedIssues(issues []result.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		pi, pj := issues[i].Pos, issues[j].Pos
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		if pi.Column != pj.Column {
+			return pi.Column < pj.Column
+		}
+		if issues[i].FromLinter != issues[j].FromLinter {
+			return issues[i].FromLinter < issues[j].FromLinter
+		}
+		return issues[i].Text < issues[j].Text
+	})
+}
+
+// mergeReportData adds report data of a shard: a linter is enabled if it's
+// enabled in any shard.
+func mergeReportData(to, from *report.Data) {
+	linterIndexes := map[string]int{}
+	for i, ld := range to.Linters {
+		linterIndexes[ld.Name] = i
+	}
+	for _, ld := range from.Linters {
+		if i, ok := linterIndexes[ld.Name]; ok {
+			to.Linters[i].Enabled = to.Linters[i].Enabled || ld.Enabled
+			continue
+		}
+		linterIndexes[ld.Name] = len(to.Linters)
+		to.Linters = append(to.Linters, ld)
+	}
+
+	to.Warnings = append(to.Warnings, from.Warnings...)
+	if to.Error == "" {
+		to.Error = from.Error
+	}
+}
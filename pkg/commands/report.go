@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/printers"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func (e *Executor) initReport() {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Work with JSON reports of 'golangci-lint run --out-format json'",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				e.log.Fatalf("Usage: golangci-lint report")
+			}
+			if err := cmd.Help(); err != nil {
+				e.log.Fatalf("Can't run help: %s", err)
+			}
+		},
+	}
+	e.rootCmd.AddCommand(reportCmd)
+
+	diffCmd := &cobra.Command{
+		Use: "diff old.json new.json",
+		Short: "Print new and fixed issues and per-linter deltas of issues count between two reports, " +
+			"exit with --issues-exit-code if there are new issues",
+		Args: cobra.ExactArgs(2),
+		Run:  e.executeReportDiff,
+	}
+	reportCmd.AddCommand(diffCmd)
+	e.initRunConfiguration(diffCmd)
+}
+
+func (e *Executor) executeReportDiff(_ *cobra.Command, args []string) {
+	if err := e.runReportDiff(context.Background(), args[0], args[1]); err != nil {
+		e.log.Errorf("Report diff error: %s", err)
+		e.exitCode = exitcodes.Failure
+	}
+}
+
+func readJSONReport(path string) (*printers.JSONResult, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't read report %s", path)
+	}
+
+	var res printers.JSONResult
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, errors.Wrapf(err, "can't parse JSON report %s", path)
+	}
+
+	return &res, nil
+}
+
+// linterDelta is a change of count of issues of a linter between reports.
+type linterDelta struct {
+	Linter   string
+	OldCount int
+	NewCount int
+	Delta    int
+}
+
+func getLinterDeltas(oldIssues, newIssues []result.Issue) []linterDelta {
+	counts := map[string]*linterDelta{}
+	get := func(name string) *linterDelta {
+		if counts[name] == nil {
+			counts[name] = &linterDelta{Linter: name}
+		}
+		return counts[name]
+	}
+	for _, i := range oldIssues {
+		get(i.FromLinter).OldCount++
+	}
+	for _, i := range newIssues {
+		get(i.FromLinter).NewCount++
+	}
+
+	var ret []linterDelta
+	for _, d := range counts {
+		d.Delta = d.NewCount - d.OldCount
+		ret = append(ret, *d)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Linter < ret[j].Linter
+	})
+	return ret
+}
+
+type reportDiffJSON struct {
+	New            []result.Issue
+	Fixed          []result.Issue
+	UnchangedCount int
+	Linters        []linterDelta
+}
+
+func (e *Executor) runReportDiff(ctx context.Context, oldPath, newPath string) error {
+	switch e.cfg.Output.Format {
+	case config.OutFormatJSON, config.OutFormatColoredLineNumber, config.OutFormatLineNumber, config.OutFormatTab:
+	default:
+		return errors.Errorf("format %s can't show fixed issues and deltas: use %s, %s, %s or %s",
+			e.cfg.Output.Format, config.OutFormatColoredLineNumber, config.OutFormatLineNumber,
+			config.OutFormatTab, config.OutFormatJSON)
+	}
+
+	oldReport, err := readJSONReport(oldPath)
+	if err != nil {
+		return err
+	}
+	newReport, err := readJSONReport(newPath)
+	if err != nil {
+		return err
+	}
+
+	// Suppressed issues of reports made with --show-suppressed aren't compared.
+	oldIssues, newIssues := withoutSuppressedIssues(oldReport.Issues), withoutSuppressedIssues(newReport.Issues)
+	diff := result.DiffIssues(oldIssues, newIssues)
+	deltas := getLinterDeltas(oldIssues, newIssues)
+	if len(diff.New) != 0 {
+		e.exitCode = e.cfg.Run.ExitCodeIfIssuesFound
+	}
+
+	if e.cfg.Output.Format == config.OutFormatJSON {
+		return printReportDiffJSON(diff, deltas)
+	}
+	return e.printReportDiffText(ctx, diff, deltas)
+}
+
+func printReportDiffJSON(diff *result.IssuesDiff, deltas []linterDelta) error {
+	res := reportDiffJSON{
+		New:            diff.New,
+		Fixed:          diff.Fixed,
+		UnchangedCount: len(diff.Unchanged),
+		Linters:        deltas,
+	}
+	if res.New == nil {
+		res.New = []result.Issue{}
+	}
+	if res.Fixed == nil {
+		res.Fixed = []result.Issue{}
+	}
+
+	outputJSON, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(logutils.StdOut, string(outputJSON))
+	return nil
+}
+
+func (e *Executor) printReportDiffText(ctx context.Context, diff *result.IssuesDiff, deltas []linterDelta) error {
+	fmt.Fprintf(logutils.StdOut, "New issues (%d):\n", len(diff.New))
+	if err := e.printIssues(ctx, diff.New); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(logutils.StdOut, "\nFixed issues (%d):\n", len(diff.Fixed))
+	if err := e.printIssues(ctx, diff.Fixed); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(logutils.StdOut, "\nUnchanged issues: %d\n\n", len(diff.Unchanged))
+	w := tabwriter.NewWriter(logutils.StdOut, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Linter\tOld\tNew\tDelta\t")
+	for _, d := range deltas {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%+d\t\n", d.Linter, d.OldCount, d.NewCount, d.Delta)
+	}
+	return w.Flush()
+}
+
+func withoutSuppressedIssues(issues []result.Issue) []result.Issue {
+	var ret []result.Issue
+	for _, i := range issues {
+		if i.Suppression == nil {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
+
+func (e *Executor) printIssues(ctx context.Context, issues []result.Issue) error {
+	p, err := e.createPrinter()
+	if err != nil {
+		return err
+	}
+
+	issuesCh := make(chan result.Issue, len(issues))
+	for _, i := range issues {
+		issuesCh <- i
+	}
+	close(issuesCh)
+
+	if err = p.Print(ctx, issuesCh); err != nil {
+		return errors.Wrap(err, "can't print issues")
+	}
+	return nil
+}
@@ -48,6 +48,10 @@ func (e *Executor) persistentPreRun(_ *cobra.Command, _ []string) {
 			e.log.Fatalf("Can't start tracing: %s", err)
 		}
 	}
+
+	if e.cfg.Run.ProfileOutPath != "" {
+		e.profiler.Start()
+	}
 }
 
 func (e *Executor) persistentPostRun(_ *cobra.Command, _ []string) {
@@ -72,6 +76,11 @@ func (e *Executor) persistentPostRun(_ *cobra.Command, _ []string) {
 	if e.cfg.Run.TracePath != "" {
 		trace.Stop()
 	}
+	if e.cfg.Run.ProfileOutPath != "" {
+		if err := e.profiler.Save(e.cfg.Run.ProfileOutPath); err != nil {
+			e.log.Fatalf("Can't save profile: %s", err)
+		}
+	}
 
 	os.Exit(e.exitCode)
 }
@@ -151,10 +160,12 @@ func initRootFlagSet(fs *pflag.FlagSet, cfg *config.Config, needVersionOption bo
 	fs.StringVar(&cfg.Run.CPUProfilePath, "cpu-profile-path", "", wh("Path to CPU profile output file"))
 	fs.StringVar(&cfg.Run.MemProfilePath, "mem-profile-path", "", wh("Path to memory profile output file"))
 	fs.StringVar(&cfg.Run.TracePath, "trace-path", "", wh("Path to trace output file"))
+	fs.StringVar(&cfg.Run.ProfileOutPath, "profile-out", "",
+		wh("Path to Chrome trace event JSON output file with timings of linters, analyzers and processors"))
 	fs.IntVarP(&cfg.Run.Concurrency, "concurrency", "j", getDefaultConcurrency(), wh("Concurrency (default NumCPU)"))
 	if needVersionOption {
 		fs.BoolVar(&cfg.Run.PrintVersion, "version", false, wh("Print version"))
 	}
 
-	fs.StringVar(&cfg.Output.Color, "color", "auto", wh("Use color when printing; can be 'always', 'auto', or 'never'"))
+	fs.StringVar(&cfg.Output.Color, "color", config.NewDefault().Output.Color, wh("Use color when printing; can be 'always', 'auto', or 'never'"))
 }
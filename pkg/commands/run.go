@@ -2,10 +2,12 @@ package commands
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -18,8 +20,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/golangci/golangci-lint/internal/cache"
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/gitutil"
 	"github.com/golangci/golangci-lint/pkg/lint"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
 	"github.com/golangci/golangci-lint/pkg/logutils"
@@ -71,29 +75,50 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 		}
 	}
 
+	defaults := config.NewDefault()
+
 	// Output config
 	oc := &cfg.Output
 	fs.StringVar(&oc.Format, "out-format",
-		config.OutFormatColoredLineNumber,
+		defaults.Output.Format,
 		wh(fmt.Sprintf("Format of output: %s", strings.Join(config.OutFormats, "|"))))
-	fs.BoolVar(&oc.PrintIssuedLine, "print-issued-lines", true, wh("Print lines of code with issue"))
-	fs.BoolVar(&oc.PrintLinterName, "print-linter-name", true, wh("Print linter name in issue line"))
+	fs.BoolVar(&oc.PrintIssuedLine, "print-issued-lines", defaults.Output.PrintIssuedLine, wh("Print lines of code with issue"))
+	fs.BoolVar(&oc.PrintLinterName, "print-linter-name", defaults.Output.PrintLinterName, wh("Print linter name in issue line"))
+	fs.BoolVar(&oc.PrintRuleID, "print-rule-id", false,
+		wh("Print rule ID after linter name in issue line, e.g. gosec/G104, and in checkstyle and junit-xml formats"))
 	fs.BoolVar(&oc.PrintWelcomeMessage, "print-welcome", false, wh("Print welcome message"))
 	hideFlag("print-welcome") // no longer used
 
 	// Run config
 	rc := &cfg.Run
 	fs.IntVar(&rc.ExitCodeIfIssuesFound, "issues-exit-code",
-		exitcodes.IssuesFound, wh("Exit code when issues were found"))
+		defaults.Run.ExitCodeIfIssuesFound, wh("Exit code when issues were found"))
 	fs.StringSliceVar(&rc.BuildTags, "build-tags", nil, wh("Build tags"))
-	fs.DurationVar(&rc.Deadline, "deadline", time.Minute, wh("Deadline for total work"))
-	fs.BoolVar(&rc.AnalyzeTests, "tests", true, wh("Analyze tests (*_test.go)"))
+	fs.DurationVar(&rc.Deadline, "deadline", defaults.Run.Deadline, wh("Deadline for total work"))
+	fs.BoolVar(&rc.AnalyzeTests, "tests", defaults.Run.AnalyzeTests, wh("Analyze tests (*_test.go)"))
 	fs.BoolVar(&rc.PrintResourcesUsage, "print-resources-usage", false,
 		wh("Print avg and max memory usage of golangci-lint and total time"))
+	fs.IntVar(&rc.MaxMemory, "max-memory", 0,
+		wh("Soft limit of memory usage in megabytes: loading and analysis of packages are throttled "+
+			"to not exceed it and dependencies without cached facts aren't analyzed above it. Set to 0 to disable"))
+	fs.BoolVar(&rc.UseDaemon, "daemon", false,
+		wh("Get issues from 'golangci-lint serve' running in the current directory instead of analyzing"))
+	fs.StringVar(&rc.DaemonSocket, "daemon-socket", "",
+		wh("Path to Unix socket of 'golangci-lint serve', it's chosen by the current directory by default"))
+	fs.StringVar(&rc.StdinFilename, "stdin-filename", "",
+		wh("Read content of the file with the given path from stdin, e.g. an unsaved editor buffer. "+
+			"The directory of the file is analyzed if no paths are given"))
+	fs.BoolVar(&rc.Watch, "watch", false,
+		wh("Re-run analysis of affected packages when Go files, go.mod or the config change"))
+	fs.StringVar(&rc.Shard, "shard", "",
+		wh("Analyze only the shard `i/n` of package directories split by sizes, e.g. 2/4. "+
+			"Limits and deduplication of issues aren't applied: merge JSON reports of all shards by 'golangci-lint merge'"))
+	fs.StringVar(&rc.ExplainLocation, "explain-location", "",
+		wh("Print to stderr issues reported by linters at the `location` file.go:line and which processors hid them and why"))
 	fs.StringVarP(&rc.Config, "config", "c", "", wh("Read config from file path `PATH`"))
 	fs.BoolVar(&rc.NoConfig, "no-config", false, wh("Don't read config"))
 	fs.StringSliceVar(&rc.SkipDirs, "skip-dirs", nil, wh("Regexps of directories to skip"))
-	fs.BoolVar(&rc.UseDefaultSkipDirs, "skip-dirs-use-default", true, getDefaultDirectoryExcludeHelp())
+	fs.BoolVar(&rc.UseDefaultSkipDirs, "skip-dirs-use-default", defaults.Run.UseDefaultSkipDirs, getDefaultDirectoryExcludeHelp())
 	fs.StringSliceVar(&rc.SkipFiles, "skip-files", nil, wh("Regexps of files to skip"))
 
 	// Linters settings config
@@ -112,7 +137,7 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 	fs.StringVar(&lsc.Errcheck.Exclude, "errcheck.exclude", "",
 		"Path to a file containing a list of functions to exclude from checking")
 	hideFlag("errcheck.exclude")
-	fs.StringVar(&lsc.Errcheck.Ignore, "errcheck.ignore", "fmt:.*",
+	fs.StringVar(&lsc.Errcheck.Ignore, "errcheck.ignore", defaults.LintersSettings.Errcheck.Ignore,
 		`Comma-separated list of pairs of the form pkg:regex. The regex is used to ignore names within pkg`)
 	hideFlag("errcheck.ignore")
 
@@ -120,15 +145,15 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 		"Govet: check for shadowed variables")
 	hideFlag("govet.check-shadowing")
 
-	fs.Float64Var(&lsc.Golint.MinConfidence, "golint.min-confidence", 0.8,
+	fs.Float64Var(&lsc.Golint.MinConfidence, "golint.min-confidence", defaults.LintersSettings.Golint.MinConfidence,
 		"Golint: minimum confidence of a problem to print it")
 	hideFlag("golint.min-confidence")
 
-	fs.BoolVar(&lsc.Gofmt.Simplify, "gofmt.simplify", true, "Gofmt: simplify code")
+	fs.BoolVar(&lsc.Gofmt.Simplify, "gofmt.simplify", defaults.LintersSettings.Gofmt.Simplify, "Gofmt: simplify code")
 	hideFlag("gofmt.simplify")
 
 	fs.IntVar(&lsc.Gocyclo.MinComplexity, "gocyclo.min-complexity",
-		30, "Minimal complexity of function to report it")
+		defaults.LintersSettings.Gocyclo.MinComplexity, "Minimal complexity of function to report it")
 	hideFlag("gocyclo.min-complexity")
 
 	fs.BoolVar(&lsc.Maligned.SuggestNewOrder, "maligned.suggest-new", false,
@@ -136,14 +161,14 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 	hideFlag("maligned.suggest-new")
 
 	fs.IntVar(&lsc.Dupl.Threshold, "dupl.threshold",
-		150, "Dupl: Minimal threshold to detect copy-paste")
+		defaults.LintersSettings.Dupl.Threshold, "Dupl: Minimal threshold to detect copy-paste")
 	hideFlag("dupl.threshold")
 
 	fs.IntVar(&lsc.Goconst.MinStringLen, "goconst.min-len",
-		3, "Goconst: minimum constant string length")
+		defaults.LintersSettings.Goconst.MinStringLen, "Goconst: minimum constant string length")
 	hideFlag("goconst.min-len")
 	fs.IntVar(&lsc.Goconst.MinOccurrencesCount, "goconst.min-occurrences",
-		3, "Goconst: minimum occurrences of constant string count to trigger issue")
+		defaults.LintersSettings.Goconst.MinOccurrencesCount, "Goconst: minimum occurrences of constant string count to trigger issue")
 	hideFlag("goconst.min-occurrences")
 
 	// (@dixonwille) These flag is only used for testing purposes.
@@ -155,7 +180,7 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 		"Depguard: check list against standard lib")
 	hideFlag("depguard.include-go-root")
 
-	fs.IntVar(&lsc.Lll.TabWidth, "lll.tab-width", 1,
+	fs.IntVar(&lsc.Lll.TabWidth, "lll.tab-width", defaults.LintersSettings.Lll.TabWidth,
 		"Lll: tab width in spaces")
 	hideFlag("lll.tab-width")
 
@@ -173,11 +198,14 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 	// Issues config
 	ic := &cfg.Issues
 	fs.StringSliceVarP(&ic.ExcludePatterns, "exclude", "e", nil, wh("Exclude issue by regexp"))
-	fs.BoolVar(&ic.UseDefaultExcludes, "exclude-use-default", true, getDefaultIssueExcludeHelp())
+	fs.BoolVar(&ic.UseDefaultExcludes, "exclude-use-default", defaults.Issues.UseDefaultExcludes, getDefaultIssueExcludeHelp())
+	fs.BoolVar(&ic.FailOnUnusedExcludes, "fail-on-unused-excludes", false,
+		wh("Fail if exclude patterns or rules from the config didn't exclude any issue. They're checked only "+
+			"when all issues are shown, not in diff or sharded runs"))
 
-	fs.IntVar(&ic.MaxIssuesPerLinter, "max-issues-per-linter", 50,
+	fs.IntVar(&ic.MaxIssuesPerLinter, "max-issues-per-linter", defaults.Issues.MaxIssuesPerLinter,
 		wh("Maximum issues count per one linter. Set to 0 to disable"))
-	fs.IntVar(&ic.MaxSameIssues, "max-same-issues", 3,
+	fs.IntVar(&ic.MaxSameIssues, "max-same-issues", defaults.Issues.MaxSameIssues,
 		wh("Maximum count of issues with the same text. Set to 0 to disable"))
 
 	fs.BoolVarP(&ic.Diff, "new", "n", false,
@@ -191,6 +219,15 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager, is
 		wh("Show only new issues created after git revision `REV`"))
 	fs.StringVar(&ic.DiffPatchFilePath, "new-from-patch", "",
 		wh("Show only new issues created in git patch with file path `PATH`"))
+	fs.BoolVar(&ic.DiffAffectedOnly, "new-affected-only", false,
+		wh("Analyze only packages containing changed files and packages importing them. "+
+			"Changes are taken like in --new, --new-from-rev or --new-from-patch, --new is used if none of them is set"))
+	fs.BoolVar(&ic.DiffStaged, "staged", false,
+		wh("Analyze contents of files in the git index instead of the working tree and show only issues "+
+			"in staged changes. It's useful in pre-commit hooks"))
+	fs.BoolVar(&ic.ShowSuppressed, "show-suppressed", false,
+		wh("Keep suppressed issues with the reason of suppression: json and sarif formats print them, "+
+			"they don't affect the exit code"))
 	fs.BoolVar(&ic.NeedFix, "fix", false, "Fix found issues (if it's supported by the linter)")
 }
 
@@ -200,6 +237,8 @@ func (e *Executor) initRunConfiguration(cmd *cobra.Command) {
 	initFlagSet(fs, e.cfg, e.DBManager, true)
 }
 
+// getConfigForCommandLine must be called after init of commands: flags of
+// the executed command are needed to parse the command line.
 func (e *Executor) getConfigForCommandLine() (*config.Config, error) {
 	// We use another pflag.FlagSet here to not set `changed` flag
 	// on cmd.Flags() options. Otherwise string slice options will be duplicated.
@@ -217,6 +256,13 @@ func (e *Executor) getConfigForCommandLine() (*config.Config, error) {
 	// cfg vs e.cfg.
 	initRootFlagSet(fs, &cfg, true)
 
+	// Flags of commands, e.g. `cache trim --older-than`, aren't in the config:
+	// add them without values to not fail on them.
+	if cmd, _, err := e.rootCmd.Find(os.Args[1:]); err == nil {
+		addIgnoredFlags(fs, cmd.Flags())
+		addIgnoredFlags(fs, cmd.InheritedFlags())
+	}
+
 	fs.Usage = func() {} // otherwise help text will be printed twice
 	if err := fs.Parse(os.Args); err != nil {
 		if err == pflag.ErrHelp {
@@ -229,6 +275,37 @@ func (e *Executor) getConfigForCommandLine() (*config.Config, error) {
 	return &cfg, nil
 }
 
+// ignoredFlagValue accepts any value of a flag of the same type.
+type ignoredFlagValue struct {
+	typ string
+}
+
+func (v ignoredFlagValue) String() string   { return "" }
+func (v ignoredFlagValue) Set(string) error { return nil }
+func (v ignoredFlagValue) Type() string     { return v.typ }
+
+// addIgnoredFlags adds to fs flags from cmdFlags which fs doesn't have.
+// Values aren't shared: parsing of fs doesn't affect cmdFlags.
+func addIgnoredFlags(fs, cmdFlags *pflag.FlagSet) {
+	cmdFlags.VisitAll(func(f *pflag.Flag) {
+		if fs.Lookup(f.Name) != nil {
+			return
+		}
+
+		shorthand := f.Shorthand
+		if shorthand != "" && fs.ShorthandLookup(shorthand) != nil {
+			shorthand = ""
+		}
+		fs.AddFlag(&pflag.Flag{
+			Name:        f.Name,
+			Shorthand:   shorthand,
+			Usage:       f.Usage,
+			Value:       ignoredFlagValue{typ: f.Value.Type()},
+			NoOptDefVal: f.NoOptDefVal, // e.g. bool flags don't take the next argument
+		})
+	})
+}
+
 func (e *Executor) initRun() {
 	e.runCmd = &cobra.Command{
 		Use:   "run",
@@ -285,6 +362,12 @@ func (e *Executor) runAnalysis(ctx context.Context, args []string) (<-chan resul
 	}
 
 	lintCtx, err := e.contextLoader.Load(ctx, enabledLinters)
+	if err == lint.ErrNoAffectedPackages || err == lint.ErrEmptyShard {
+		e.log.Infof("Nothing to analyze: %s", err)
+		issuesCh := make(chan result.Issue)
+		close(issuesCh)
+		return issuesCh, nil
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "context loading failed")
 	}
@@ -313,13 +396,60 @@ func (e *Executor) setOutputToDevNull() (savedStdout, savedStderr *os.File) {
 	return
 }
 
+// setOverlay makes the content replace the file content on disk for all
+// analysis stages.
+func (e *Executor) setOverlay(filePath string, content []byte) error {
+	filePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "can't make abs path for %s", filePath)
+	}
+
+	if err = e.fileCache.SetOverlay(filePath, content); err != nil {
+		return err
+	}
+
+	// Don't reuse cached data of the package built for the content on disk.
+	cache.SetFileHash(filePath, sha256.Sum256(content))
+	return nil
+}
+
+// setStdinOverlay makes the content read from stdin replace the file
+// given by --stdin-filename for all analysis stages and returns run args.
+func (e *Executor) setStdinOverlay(args []string) ([]string, error) {
+	if e.cfg.Run.UseDaemon {
+		return nil, errors.New("--stdin-filename can't be used with --daemon")
+	}
+	if e.cfg.Issues.NeedFix {
+		return nil, errors.New("--stdin-filename can't be used with --fix: the file on disk would be overwritten")
+	}
+	if e.cfg.Issues.DiffStaged {
+		return nil, errors.New("--stdin-filename can't be used with --staged")
+	}
+
+	content, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read stdin")
+	}
+
+	if err = e.setOverlay(e.cfg.Run.StdinFilename, content); err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 {
+		args = []string{filepath.Dir(e.cfg.Run.StdinFilename)}
+	}
+	return args, nil
+}
+
 func (e *Executor) setExitCodeIfIssuesFound(issues <-chan result.Issue) <-chan result.Issue {
 	resCh := make(chan result.Issue, 1024)
 
 	go func() {
 		issuesFound := false
 		for i := range issues {
-			issuesFound = true
+			if i.Suppression == nil {
+				issuesFound = true
+			}
 			resCh <- i
 		}
 
@@ -347,7 +477,28 @@ func (e *Executor) runAndPrint(ctx context.Context, args []string) error {
 		}()
 	}
 
-	issues, err := e.runAnalysis(ctx, args)
+	if e.cfg.Run.StdinFilename != "" {
+		var err error
+		if args, err = e.setStdinOverlay(args); err != nil {
+			return err
+		}
+	}
+
+	var stagedFiles []string
+	if e.cfg.Issues.DiffStaged {
+		var err error
+		if stagedFiles, err = e.setStagedOverlay(); err != nil {
+			return err
+		}
+	}
+
+	var issues <-chan result.Issue
+	var err error
+	if e.cfg.Run.UseDaemon {
+		issues, err = e.runWithDaemon(ctx, args)
+	} else {
+		issues, err = e.runAnalysis(ctx, args)
+	}
 	if err != nil {
 		return err // XXX: don't loose type
 	}
@@ -365,6 +516,14 @@ func (e *Executor) runAndPrint(ctx context.Context, args []string) error {
 
 	e.fileCache.PrintStats(e.log)
 
+	if e.cfg.Issues.DiffStaged && e.cfg.Issues.NeedFix && len(stagedFiles) != 0 {
+		// Staged files had no unstaged changes: staging fixes keeps
+		// the index and the working tree the same.
+		if err = gitutil.Add(stagedFiles); err != nil {
+			return errors.Wrap(err, "failed to stage fixed files")
+		}
+	}
+
 	return nil
 }
 
@@ -377,23 +536,37 @@ func (e *Executor) createPrinter() (printers.Printer, error) {
 	case config.OutFormatColoredLineNumber, config.OutFormatLineNumber:
 		p = printers.NewText(e.cfg.Output.PrintIssuedLine,
 			format == config.OutFormatColoredLineNumber, e.cfg.Output.PrintLinterName,
-			e.log.Child("text_printer"))
+			e.cfg.Output.PrintRuleID, e.log.Child("text_printer"))
 	case config.OutFormatTab:
-		p = printers.NewTab(e.cfg.Output.PrintLinterName, e.log.Child("tab_printer"))
+		p = printers.NewTab(e.cfg.Output.PrintLinterName, e.cfg.Output.PrintRuleID, e.log.Child("tab_printer"))
 	case config.OutFormatCheckstyle:
-		p = printers.NewCheckstyle()
+		p = printers.NewCheckstyle(e.cfg.Output.PrintRuleID)
 	case config.OutFormatCodeClimate:
 		p = printers.NewCodeClimate()
 	case config.OutFormatJunitXML:
-		p = printers.NewJunitXML()
+		p = printers.NewJunitXML(e.cfg.Output.PrintRuleID)
+	case config.OutFormatSarif:
+		p = printers.NewSarif()
 	default:
 		return nil, fmt.Errorf("unknown output format %s", format)
 	}
 
+	if format != config.OutFormatJSON && format != config.OutFormatSarif {
+		p = printers.NewHideSuppressed(p)
+	}
+
 	return p, nil
 }
 
 func (e *Executor) executeRun(_ *cobra.Command, args []string) {
+	if e.cfg.Run.Watch {
+		if err := e.runWatch(args); err != nil {
+			e.log.Errorf("Watching error: %s", err)
+			e.exitCode = exitcodes.Failure
+		}
+		return
+	}
+
 	needTrackResources := e.cfg.Run.IsVerbose || e.cfg.Run.PrintResourcesUsage
 	trackResourcesEndCh := make(chan struct{})
 	defer func() { // XXX: this defer must be before ctx.cancel defer
@@ -0,0 +1,445 @@
+package commands
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/internal/cache"
+	"github.com/golangci/golangci-lint/pkg/daemon"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const daemonWatchInterval = 300 * time.Millisecond
+
+func (e *Executor) initServe() {
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run a daemon keeping analysis results warm: `golangci-lint run --daemon` gets issues from it",
+		Run:   e.executeServe,
+	}
+	e.rootCmd.AddCommand(serveCmd)
+	e.initRunConfiguration(serveCmd)
+}
+
+func (e *Executor) getDaemonSocketPath() (string, error) {
+	if e.cfg.Run.DaemonSocket != "" {
+		return e.cfg.Run.DaemonSocket, nil
+	}
+
+	return daemon.DefaultSocketPath(".")
+}
+
+// forgetChangedFiles drops state kept for the files given by absolute paths:
+// the loader, parsed unchanged files and cached results of unchanged packages
+// stay warm. Cached results of packages importing the changed packages aren't
+// used too: hashes of packages include hashes of their dependencies.
+func (e *Executor) forgetChangedFiles(absPaths []string) {
+	e.fileCache.Forget(absPaths)
+	e.lineCache.Forget(absPaths)
+	e.contextLoader.ForgetFiles(absPaths)
+	for _, path := range absPaths {
+		cache.ForgetFileHash(path)
+	}
+}
+
+// prepareIncrementalAnalysis configures the executor for servers analyzing
+// only changed packages multiple times.
+func (e *Executor) prepareIncrementalAnalysis(ctx context.Context) {
+	if err := e.goenv.Discover(ctx); err != nil {
+		e.log.Warnf("Failed to discover go env: %s", err)
+	}
+
+	// Don't allow linters and loader to print anything.
+	log.SetOutput(ioutil.Discard)
+
+	e.contextLoader.KeepParsedFiles()
+
+	// Incremental results can't respect limits over all issues.
+	e.cfg.Issues.MaxIssuesPerLinter = 0
+	e.cfg.Issues.MaxSameIssues = 0
+	e.cfg.Issues.DiffAffectedOnly = false
+	e.cfg.Issues.NeedFix = false
+	e.cfg.Run.IsIncremental = true
+	// Editors and clients of the daemon show all returned issues.
+	e.cfg.Issues.ShowSuppressed = false
+}
+
+// analyzeIncrementally runs the analysis of args reusing state of previous
+// analyses and collects its issues. State of changed files must be dropped
+// by forgetChangedFiles before.
+func (e *Executor) analyzeIncrementally(ctx context.Context, args []string) ([]result.Issue, error) {
+	e.reportData = report.Data{} // e.log keeps the pointer to it
+
+	// Packages are loaded again by every analysis.
+	e.pkgCache.ForgetPackages()
+	e.loadGuard.ForgetPackages()
+
+	issuesCh, err := e.runAnalysis(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []result.Issue
+	for i := range issuesCh {
+		issues = append(issues, i)
+	}
+	return issues, nil
+}
+
+func (e *Executor) executeServe(_ *cobra.Command, args []string) {
+	ctx := context.Background()
+	e.prepareIncrementalAnalysis(ctx)
+
+	d, err := newLintDaemon(e, args)
+	if err != nil {
+		e.log.Fatalf("Can't start daemon: %s", err)
+	}
+
+	socketPath, err := e.getDaemonSocketPath()
+	if err != nil {
+		e.log.Fatalf("Can't start daemon: %s", err)
+	}
+
+	srv := daemon.NewServer(socketPath, d, e.cfg.Run.Deadline, e.log.Child("server"))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go d.watcher.Watch(ctx, daemonWatchInterval, d.addChanges, func(err error) {
+		e.log.Warnf("Failed to find changed files: %s", err)
+	})
+	go d.analyzeInBackground(ctx, e.cfg.Run.Deadline)
+
+	if err := srv.Serve(ctx); err != nil {
+		e.log.Fatalf("Serving failed: %s", err)
+	}
+}
+
+// lintDaemon keeps issues of all packages and re-analyzes only packages
+// affected by changed files.
+type lintDaemon struct {
+	e         *Executor
+	args      []string
+	watcher   *fsutils.Watcher
+	store     *daemon.IssuesStore
+	startedAt time.Time
+	changedCh chan struct{}
+
+	changesMu sync.Mutex
+	changes   []fsutils.FileChange
+
+	mu          sync.Mutex // serializes analysis
+	initialized bool
+	graph       *lint.PackagesGraph
+	fileHeaders map[string]string // headers of Go files define the packages graph
+	runs        int
+	lastRun     *daemon.RunInfo
+	lastReport  report.Data
+}
+
+func newLintDaemon(e *Executor, args []string) (*lintDaemon, error) {
+	w, err := fsutils.NewWatcher(".", fsutils.IsGoSourceFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start watching files")
+	}
+
+	d := &lintDaemon{
+		e:           e,
+		args:        args,
+		watcher:     w,
+		store:       daemon.NewIssuesStore(),
+		startedAt:   time.Now(),
+		changedCh:   make(chan struct{}, 1),
+		fileHeaders: map[string]string{},
+	}
+	for _, f := range w.Files() {
+		d.fileHeaders[f] = readGoFileHeader(f)
+	}
+
+	return d, nil
+}
+
+// readGoFileHeader returns package name, build constraints and imports of the file.
+func readGoFileHeader(path string) string {
+	if !strings.HasSuffix(path, ".go") {
+		return ""
+	}
+
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return "" // it will be reported by the analysis
+	}
+
+	parts := []string{f.Name.Name}
+	for _, cg := range f.Comments {
+		if cg.Pos() < f.Package {
+			parts = append(parts, cg.Text())
+		}
+	}
+	for _, imp := range f.Imports {
+		parts = append(parts, imp.Path.Value)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func (d *lintDaemon) addChanges(changes []fsutils.FileChange) {
+	d.changesMu.Lock()
+	d.changes = append(d.changes, changes...)
+	d.changesMu.Unlock()
+
+	select {
+	case d.changedCh <- struct{}{}:
+	default: // analysis is already scheduled
+	}
+}
+
+func (d *lintDaemon) takeChanges() []fsutils.FileChange {
+	d.changesMu.Lock()
+	defer d.changesMu.Unlock()
+
+	ret := d.changes
+	d.changes = nil
+	return ret
+}
+
+// analyzeInBackground analyzes changes as soon as they are found: results
+// will be ready by the time of a lint request.
+func (d *lintDaemon) analyzeInBackground(ctx context.Context, timeout time.Duration) {
+	d.addChanges(nil) // the initial analysis
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.changedCh:
+			runCtx, cancel := context.WithTimeout(ctx, timeout)
+			if err := d.refresh(runCtx); err != nil {
+				d.e.log.Warnf("Analysis failed: %s", err)
+			}
+			cancel()
+		}
+	}
+}
+
+// graphChanged returns true if the changes can change the packages graph:
+// package names, files of packages or their imports.
+func (d *lintDaemon) graphChanged(changes []fsutils.FileChange) bool {
+	ret := false
+	for _, c := range changes {
+		switch c.Kind {
+		case fsutils.FileCreated, fsutils.FileModified:
+			header := readGoFileHeader(c.Path)
+			if prevHeader, ok := d.fileHeaders[c.Path]; !ok || header != prevHeader {
+				ret = true
+			}
+			d.fileHeaders[c.Path] = header
+		case fsutils.FileDeleted:
+			delete(d.fileHeaders, c.Path)
+			ret = true
+		}
+	}
+
+	return ret
+}
+
+func (d *lintDaemon) runAnalysis(ctx context.Context, args []string) ([]result.Issue, error) {
+	issues, err := d.e.analyzeIncrementally(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	d.lastReport = d.e.reportData
+	return issues, nil
+}
+
+func (d *lintDaemon) refresh(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	changes := d.takeChanges()
+	if d.initialized && len(changes) == 0 {
+		return nil
+	}
+
+	startedAt := time.Now()
+	runArgs, err := d.analyzeChanges(ctx, changes)
+	if err != nil {
+		// Retry on the next request: e.g. a file can be saved in the middle of editing.
+		d.changesMu.Lock()
+		d.changes = append(changes, d.changes...)
+		d.changesMu.Unlock()
+		return err
+	}
+
+	d.runs++
+	d.lastRun = &daemon.RunInfo{
+		At:       startedAt,
+		Duration: time.Since(startedAt),
+		Args:     runArgs,
+	}
+	d.e.log.Infof("Analyzed %v in %s", runArgs, d.lastRun.Duration)
+	return nil
+}
+
+func (d *lintDaemon) analyzeChanges(ctx context.Context, changes []fsutils.FileChange) ([]string, error) {
+	if d.graphChanged(changes) {
+		d.graph = nil
+	}
+
+	fullRun := !d.initialized
+	var changedFiles []string
+	for _, c := range changes {
+		changedFiles = append(changedFiles, c.Path)
+		if base := filepath.Base(c.Path); base == "go.mod" || base == "go.sum" {
+			fullRun = true
+		}
+	}
+	d.e.forgetChangedFiles(changedFiles)
+
+	if fullRun {
+		issues, err := d.runAnalysis(ctx, d.args)
+		if err != nil {
+			return nil, err
+		}
+		d.store.Reset(issues)
+		d.initialized = true
+		if len(d.args) == 0 {
+			return []string{"./..."}, nil
+		}
+		return d.args, nil
+	}
+
+	// Run args are overwritten by every analysis.
+	d.e.cfg.Run.Args = d.args
+	if d.graph == nil {
+		g, err := d.e.contextLoader.LoadPackagesGraph(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d.graph = g
+	}
+
+	runArgs, err := d.e.contextLoader.AffectedArgs(d.graph, changedFiles)
+	if err == lint.ErrNoAffectedPackages {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := d.runAnalysis(ctx, runArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, arg := range runArgs {
+		dir, err := filepath.Abs(arg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't make abs path for %s", arg)
+		}
+		dirs = append(dirs, dir)
+	}
+	d.store.Replace(dirs, issues)
+	return runArgs, nil
+}
+
+func (d *lintDaemon) Lint(ctx context.Context, req *daemon.Request) (*daemon.Response, error) {
+	// Don't wait for the watcher: a file could be saved right before the request.
+	changes, err := d.watcher.Poll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find changed files")
+	}
+	d.addChanges(changes)
+
+	if err = d.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	lastReport := d.lastReport
+	d.mu.Unlock()
+
+	return &daemon.Response{
+		Issues: d.store.Issues(req.Files),
+		Report: &lastReport,
+	}, nil
+}
+
+func (d *lintDaemon) Status() *daemon.Status {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	wd, _ := os.Getwd()
+	return &daemon.Status{
+		Dir:          wd,
+		PID:          os.Getpid(),
+		StartedAt:    d.startedAt,
+		Runs:         d.runs,
+		TrackedFiles: len(d.watcher.Files()),
+		CachedIssues: d.store.Count(),
+		LastRun:      d.lastRun,
+	}
+}
+
+// runWithDaemon gets issues from `golangci-lint serve` instead of analyzing.
+func (e *Executor) runWithDaemon(ctx context.Context, args []string) (<-chan result.Issue, error) {
+	if e.cfg.Run.Shard != "" {
+		return nil, errors.New("--daemon can't be used with --shard")
+	}
+	if e.cfg.Run.ExplainLocation != "" {
+		return nil, errors.New("--daemon can't be used with --explain-location")
+	}
+	if e.cfg.Issues.ShowSuppressed {
+		return nil, errors.New("--daemon can't be used with --show-suppressed")
+	}
+
+	var files []string
+	for _, arg := range args {
+		// The daemon can run in another working directory.
+		path, err := filepath.Abs(strings.TrimSuffix(arg, "/..."))
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't make abs path for %s", arg)
+		}
+		files = append(files, path)
+	}
+	sort.Strings(files)
+
+	socketPath, err := e.getDaemonSocketPath()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := daemon.NewClient(socketPath).Do(ctx, &daemon.Request{
+		Command: daemon.CommandLint,
+		Files:   files,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Report != nil {
+		e.reportData = *resp.Report
+	}
+
+	issuesCh := make(chan result.Issue, len(resp.Issues))
+	for _, i := range resp.Issues {
+		issuesCh <- i
+	}
+	close(issuesCh)
+	return issuesCh, nil
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/gitutil"
+)
+
+// setStagedOverlay makes the analysis see the git index instead of the
+// working tree and returns staged Go files. Go files with unstaged changes,
+// including files deleted only in the working tree, are overlaid by their
+// contents in the index. Untracked Go files, including files deleted only in
+// the index, are overlaid by empty files of their packages: they aren't
+// committed and their declarations mustn't hide or add issues. Ignored files,
+// e.g. generated ones, are analyzed as they are.
+func (e *Executor) setStagedOverlay() ([]string, error) {
+	switch {
+	case e.cfg.Run.UseDaemon:
+		return nil, errors.New("--staged can't be used with --daemon")
+	case e.cfg.Issues.DiffFromRevision != "" || e.cfg.Issues.DiffPatchFilePath != "":
+		return nil, errors.New("--staged can't be used with --new-from-rev or --new-from-patch")
+	}
+
+	stagedGoFiles, err := getGoFiles(gitutil.StagedFiles())
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get staged files")
+	}
+	unstagedGoFiles, err := getGoFiles(gitutil.UnstagedFiles())
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get unstaged files")
+	}
+	untrackedGoFiles, err := getGoFiles(gitutil.UntrackedFiles())
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get untracked files")
+	}
+
+	if e.cfg.Issues.NeedFix {
+		staged := map[string]bool{}
+		for _, f := range stagedGoFiles {
+			staged[f] = true
+		}
+		for _, f := range unstagedGoFiles {
+			if staged[f] {
+				return nil, errors.Errorf("--fix can't be used with --staged: staged file %s has unstaged changes, "+
+					"stage or stash them first", f)
+			}
+		}
+	}
+
+	for _, f := range unstagedGoFiles {
+		content, err := gitutil.IndexFileContent(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't get content of %s in the index", f)
+		}
+		if err = e.setOverlay(f, content); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range untrackedGoFiles {
+		content, err := getEmptyGoFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if err = e.setOverlay(f, content); err != nil {
+			return nil, err
+		}
+	}
+
+	e.log.Infof("Analyzing the index: %d staged Go files, overlaid %d unstaged and %d untracked Go files",
+		len(stagedGoFiles), len(unstagedGoFiles), len(untrackedGoFiles))
+	return stagedGoFiles, nil
+}
+
+func getGoFiles(files []string, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []string
+	for _, f := range files {
+		if strings.HasSuffix(f, ".go") {
+			ret = append(ret, f)
+		}
+	}
+	return ret, nil
+}
+
+// getEmptyGoFile returns a content of a Go file without declarations in
+// the package of the file: go list still lists the file from the disk.
+func getEmptyGoFile(path string) ([]byte, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't parse package clause of untracked file %s", path)
+	}
+
+	return []byte(fmt.Sprintf("package %s\n", f.Name.Name)), nil
+}
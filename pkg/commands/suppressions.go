@@ -0,0 +1,287 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/gitutil"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+const (
+	suppressionKindNolint         = "nolint"
+	suppressionKindExcludeRule    = "exclude-rule"
+	suppressionKindExcludePattern = "exclude-pattern"
+
+	allLinters = "all"
+)
+
+// suppression is a nolint directive or an exclusion from the config.
+type suppression struct {
+	Kind     string
+	Location string
+	Package  string   `json:",omitempty"` // only for nolint directives
+	Linters  []string `json:",omitempty"` // empty for all linters
+	RuleIDs  []string `json:",omitempty"`
+	Text     string   // the nolint directive or the exclusion options
+	Reason   string   `json:",omitempty"`
+	Expires  string   `json:",omitempty"`
+	Expired  bool     `json:",omitempty"`
+	Owner    string   `json:",omitempty"`
+	Author   string   `json:",omitempty"` // of the line with the nolint directive by git blame
+}
+
+type suppressionsCount struct {
+	Name  string
+	Count int
+}
+
+type suppressionsInventory struct {
+	Suppressions []suppression
+	ByLinter     []suppressionsCount
+	ByPackage    []suppressionsCount
+}
+
+func (e *Executor) initSuppressions() {
+	suppressionsCmd := &cobra.Command{
+		Use:   "suppressions",
+		Short: "Work with nolint directives and exclusions of issues in the config",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				e.log.Fatalf("Usage: golangci-lint suppressions")
+			}
+			if err := cmd.Help(); err != nil {
+				e.log.Fatalf("Can't run help: %s", err)
+			}
+		},
+	}
+	e.rootCmd.AddCommand(suppressionsCmd)
+
+	listCmd := &cobra.Command{
+		Use: "list [packages]",
+		Short: "List nolint directives with their linters, reasons and git blame authors and exclusions from " +
+			"the config with expiry dates, owners and counts per linter and per package",
+		Run: e.executeSuppressionsList,
+	}
+	suppressionsCmd.AddCommand(listCmd)
+	e.initRunConfiguration(listCmd)
+	listCmd.Flags().BoolVar(&e.suppressionsJSON, "json", false, wh("Print suppressions and counts in JSON"))
+}
+
+func (e *Executor) executeSuppressionsList(_ *cobra.Command, args []string) {
+	e.cfg.Run.Args = args
+	if err := e.runSuppressionsList(context.Background()); err != nil {
+		e.log.Errorf("Suppressions listing error: %s", err)
+		if exitErr, ok := err.(*exitcodes.ExitError); ok {
+			e.exitCode = exitErr.Code
+			return
+		}
+		e.exitCode = exitcodes.Failure
+	}
+}
+
+func (e *Executor) getConfigSuppressions(now time.Time) []suppression {
+	var ret []suppression
+	for idx, pattern := range e.cfg.Issues.ExcludePatterns {
+		ret = append(ret, suppression{
+			Kind:     suppressionKindExcludePattern,
+			Location: fmt.Sprintf("issues.exclude[%d]", idx),
+			Text:     fmt.Sprintf("text: %q", pattern),
+		})
+	}
+
+	for idx, r := range e.cfg.Issues.ExcludeRules {
+		r := r
+		ret = append(ret, suppression{
+			Kind:     suppressionKindExcludeRule,
+			Location: fmt.Sprintf("issues.exclude-rules[%d]", idx),
+			Linters:  r.Linters,
+			Text:     describeExcludeRule(&r),
+			Expires:  r.Expires,
+			Expired:  processors.SuppressionExpiry{Expires: r.Expires}.IsExpired(now),
+			Owner:    r.Owner,
+		})
+	}
+
+	return ret
+}
+
+func (e *Executor) getNolintSuppressions(ctx context.Context, now time.Time) ([]suppression, error) {
+	pkgs, astCache, err := e.contextLoader.LoadASTCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []suppression
+	seenFiles := map[string]bool{}
+	for _, pkg := range pkgs {
+		for _, path := range pkg.GoFiles {
+			f := astCache.Get(path)
+			if f == nil || f.Err != nil || seenFiles[f.Name] {
+				continue
+			}
+			seenFiles[f.Name] = true
+
+			directives := processors.FindNolintDirectives(f.F, f.Fset)
+			if len(directives) == 0 {
+				continue
+			}
+
+			relPath, err := fsutils.ShortestRelPath(f.Name, "")
+			if err != nil {
+				relPath = f.Name
+			}
+
+			authors, err := gitutil.BlameAuthors(relPath)
+			if err != nil {
+				e.log.Infof("Can't get authors of nolint directives: %s", err)
+			}
+
+			for _, d := range directives {
+				ret = append(ret, suppression{
+					Kind:     suppressionKindNolint,
+					Location: fmt.Sprintf("%s:%d", relPath, d.Pos.Line),
+					Package:  pkg.PkgPath,
+					Linters:  d.Linters,
+					RuleIDs:  d.RuleIDs,
+					Text:     d.Text,
+					Reason:   d.Reason,
+					Expires:  d.Expires,
+					Expired:  d.IsExpired(now),
+					Owner:    d.Owner,
+					Author:   authors[d.Pos.Line],
+				})
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func describeExcludeRule(r *config.ExcludeRule) string {
+	var parts []string
+	if len(r.Linters) != 0 {
+		parts = append(parts, fmt.Sprintf("linters: %s", strings.Join(r.Linters, ", ")))
+	}
+	for _, opt := range []struct{ name, value string }{
+		{"path", r.Path}, {"text", r.Text}, {"source", r.Source}, {"rule-id", r.RuleID},
+		{"package", r.Package}, {"function", r.Function}, {"generated-by", r.GeneratedBy},
+	} {
+		if opt.value != "" {
+			parts = append(parts, fmt.Sprintf("%s: %q", opt.name, opt.value))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// countSuppressions counts suppressions by keys, a suppression can have many keys.
+func countSuppressions(suppressions []suppression, getKeys func(s *suppression) []string) []suppressionsCount {
+	counts := map[string]int{}
+	for i := range suppressions {
+		for _, key := range getKeys(&suppressions[i]) {
+			counts[key]++
+		}
+	}
+
+	ret := make([]suppressionsCount, 0, len(counts))
+	for name, count := range counts {
+		ret = append(ret, suppressionsCount{Name: name, Count: count})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+func makeSuppressionsInventory(suppressions []suppression) *suppressionsInventory {
+	return &suppressionsInventory{
+		Suppressions: suppressions,
+		ByLinter: countSuppressions(suppressions, func(s *suppression) []string {
+			if len(s.Linters) == 0 {
+				return []string{allLinters}
+			}
+			return s.Linters
+		}),
+		ByPackage: countSuppressions(suppressions, func(s *suppression) []string {
+			if s.Package == "" {
+				return nil
+			}
+			return []string{s.Package}
+		}),
+	}
+}
+
+func (e *Executor) runSuppressionsList(ctx context.Context) error {
+	now := time.Now()
+	nolintSuppressions, err := e.getNolintSuppressions(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	inventory := makeSuppressionsInventory(append(e.getConfigSuppressions(now), nolintSuppressions...))
+	if e.suppressionsJSON {
+		outputJSON, err := json.Marshal(inventory)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(logutils.StdOut, string(outputJSON))
+		return nil
+	}
+
+	return printSuppressionsInventory(inventory)
+}
+
+func printSuppressionsInventory(inventory *suppressionsInventory) error {
+	w := tabwriter.NewWriter(logutils.StdOut, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Location\tExpires\tOwner\tAuthor\tSuppression\t")
+	for _, s := range inventory.Suppressions {
+		expires := orDash(s.Expires)
+		if s.Expired {
+			expires += " (expired)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n", s.Location, expires, orDash(s.Owner), orDash(s.Author), s.Text)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, counts := range []struct {
+		title  string
+		counts []suppressionsCount
+	}{
+		{"Linter", inventory.ByLinter}, {"Package", inventory.ByPackage},
+	} {
+		fmt.Fprintln(logutils.StdOut)
+		w := tabwriter.NewWriter(logutils.StdOut, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "%s\tSuppressions\t\n", counts.title)
+		for _, c := range counts.counts {
+			fmt.Fprintf(w, "%s\t%d\t\n", c.Name, c.Count)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
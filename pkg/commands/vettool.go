@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
+)
+
+// IsVetToolInvocation returns true if the go command runs the binary as
+// a vet tool: go vet -vettool=$(which golangci-lint). The go command passes
+// -V=full to get the tool version, -flags to get supported flags or
+// analyzer flags and a config file of a compilation unit.
+func IsVetToolInvocation(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+
+	if args[0] == "-V=full" || args[0] == "-flags" {
+		return true
+	}
+
+	for _, arg := range args[:len(args)-1] {
+		if !strings.HasPrefix(arg, "-") {
+			return false
+		}
+	}
+	return strings.HasSuffix(args[len(args)-1], ".cfg")
+}
+
+// ExecuteVetTool runs analyzers of go/analysis linters enabled in the config
+// by the unitchecker protocol: the go command passes analyzed packages and
+// facts of dependencies and caches results. It never returns.
+func ExecuteVetTool(version, commit, date string) {
+	vetArgs := os.Args
+	os.Args = []string{vetArgs[0], "run"} // vet tool arguments aren't golangci-lint flags
+	e := NewExecutor(version, commit, date)
+	os.Args = vetArgs
+
+	enabledLinters, err := e.EnabledLintersSet.Get(false)
+	if err != nil {
+		e.log.Fatalf("Can't get enabled linters: %s", err)
+	}
+
+	analyzers, err := goanalysis.VetToolAnalyzers(enabledLinters)
+	if err != nil {
+		e.log.Fatalf("Can't get analyzers: %s", err)
+	}
+	if len(analyzers) == 0 {
+		e.log.Fatalf("No go/analysis linters are enabled: only they can be run by go vet")
+	}
+
+	if vetArgs[1] == "-V=full" {
+		if err = printVetToolVersion(); err != nil {
+			e.log.Fatalf("Can't print version: %s", err)
+		}
+		os.Exit(0)
+	}
+
+	unitchecker.Main(analyzers...)
+}
+
+// printVetToolVersion prints the version in the format of the go command
+// tools. The go command uses it as a part of cache keys of vet results:
+// it must change when the binary or the config change.
+func printVetToolVersion() error {
+	h := sha256.New()
+
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "can't find executable")
+	}
+	f, err := os.Open(exe)
+	if err != nil {
+		return errors.Wrap(err, "can't open executable")
+	}
+	defer f.Close()
+	if _, err = io.Copy(h, f); err != nil {
+		return errors.Wrap(err, "can't read executable")
+	}
+
+	if configFile := viper.ConfigFileUsed(); configFile != "" {
+		configData, err := ioutil.ReadFile(configFile)
+		if err != nil {
+			return errors.Wrapf(err, "can't read config file %s", configFile)
+		}
+		h.Write(configData)
+	}
+
+	fmt.Printf("%s version devel comments-go-here buildID=%02x\n", filepath.Base(os.Args[0]), h.Sum(nil))
+	return nil
+}
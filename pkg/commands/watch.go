@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	watchPollInterval = 200 * time.Millisecond
+
+	// watchDebounce is a time without changes after the first change
+	// before an analysis: saving of multiple files isn't atomic.
+	watchDebounce = 300 * time.Millisecond
+)
+
+// runWatch analyzes all packages and then re-analyzes packages affected
+// by changed files until the process is interrupted.
+func (e *Executor) runWatch(args []string) error {
+	switch {
+	case e.cfg.Issues.NeedFix:
+		return errors.New("--watch can't be used with --fix")
+	case e.cfg.Run.UseDaemon:
+		return errors.New("--watch can't be used with --daemon")
+	case e.cfg.Run.StdinFilename != "":
+		return errors.New("--watch can't be used with --stdin-filename")
+	case e.cfg.Issues.DiffStaged:
+		return errors.New("--watch can't be used with --staged")
+	case e.cfg.Run.Shard != "":
+		return errors.New("--watch can't be used with --shard")
+	}
+
+	ctx := context.Background()
+	e.prepareIncrementalAnalysis(ctx)
+
+	d, err := newLintDaemon(e, args)
+	if err != nil {
+		return err
+	}
+
+	configFile := viper.ConfigFileUsed()
+	configModTime := getModTime(configFile)
+
+	var prevIssues []result.Issue
+	isFirstRun := true
+	d.addChanges(nil) // the initial analysis
+	for {
+		printed, err := e.watchRefresh(ctx, d, isFirstRun, &prevIssues)
+		if err != nil {
+			e.log.Errorf("Analysis failed: %s", err)
+		}
+		if printed {
+			isFirstRun = false
+		}
+
+		changes, err := waitForChanges(d.watcher, func() bool {
+			return !getModTime(configFile).Equal(configModTime)
+		})
+		if err != nil {
+			return err
+		}
+		if changes == nil { // the config was changed
+			return restartProcess()
+		}
+		d.addChanges(changes)
+	}
+}
+
+func getModTime(path string) time.Time {
+	if path == "" {
+		return time.Time{}
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return fi.ModTime()
+}
+
+// waitForChanges returns changes after a burst of them ends or nil changes
+// if configChanged returns true.
+func waitForChanges(w *fsutils.Watcher, configChanged func() bool) ([]fsutils.FileChange, error) {
+	var changes []fsutils.FileChange
+	var lastChangeAt time.Time
+	for {
+		time.Sleep(watchPollInterval)
+		if configChanged() {
+			return nil, nil
+		}
+
+		newChanges, err := w.Poll()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find changed files")
+		}
+
+		if len(newChanges) != 0 {
+			changes = append(changes, newChanges...)
+			lastChangeAt = time.Now()
+			continue
+		}
+
+		if len(changes) != 0 && time.Since(lastChangeAt) >= watchDebounce {
+			return changes, nil
+		}
+	}
+}
+
+// restartProcess replaces the process by a new one to apply the changed config.
+func restartProcess() error {
+	fmt.Fprintln(logutils.StdOut, "Config was changed, restarting...")
+
+	exe, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "can't find executable to restart")
+	}
+
+	if runtime.GOOS != "windows" {
+		return syscall.Exec(exe, os.Args, os.Environ())
+	}
+
+	// there is no exec on Windows: run the new process as a child
+	// and exit with its exit code
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		os.Exit(exitErr.ExitCode())
+	}
+	if err != nil {
+		return errors.Wrap(err, "can't restart")
+	}
+
+	os.Exit(0)
+	return nil
+}
+
+// watchRefresh re-analyzes changed packages and returns true if it printed
+// the issues: the first printed run has nothing to diff issues with.
+func (e *Executor) watchRefresh(ctx context.Context, d *lintDaemon, isFirstRun bool,
+	prevIssues *[]result.Issue) (bool, error) {
+	runCtx, cancel := context.WithTimeout(ctx, e.cfg.Run.Deadline)
+	defer cancel()
+
+	if err := d.refresh(runCtx); err != nil {
+		return false, err
+	}
+	if !isFirstRun && (d.lastRun == nil || len(d.lastRun.Args) == 0) {
+		return false, nil // nothing was re-analyzed
+	}
+
+	issues := d.store.Issues(nil)
+	if isatty.IsTerminal(os.Stdout.Fd()) {
+		fmt.Fprint(logutils.StdOut, "\033[H\033[2J") // clear the screen
+	}
+
+	if err := e.printIssues(ctx, issues); err != nil {
+		return false, err
+	}
+
+	fmt.Fprintf(logutils.StdOut, "\n%s: analyzed %s in %s, %d issues\n",
+		d.lastRun.At.Format("15:04:05"), strings.Join(d.lastRun.Args, " "),
+		d.lastRun.Duration.Round(time.Millisecond), len(issues))
+	if !isFirstRun {
+		printIssuesDiff(*prevIssues, issues)
+	}
+
+	*prevIssues = issues
+	return true, nil
+}
+
+func printIssuesDiff(prevIssues, issues []result.Issue) {
+	diff := result.DiffIssues(prevIssues, issues)
+	if len(diff.New) == 0 && len(diff.Fixed) == 0 {
+		fmt.Fprintln(logutils.StdOut, "No issues appeared or disappeared since the last run")
+		return
+	}
+
+	for _, i := range diff.New {
+		fmt.Fprintln(logutils.StdOut, color.RedString("+ %s:%d: %s (%s)", i.FilePath(), i.Line(), i.Text, i.FromLinter))
+	}
+	for _, i := range diff.Fixed {
+		fmt.Fprintln(logutils.StdOut, color.GreenString("- %s:%d: %s (%s)", i.FilePath(), i.Line(), i.Text, i.FromLinter))
+	}
+}
@@ -3,8 +3,14 @@ package config
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"runtime"
+	"strings"
 	"time"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
 )
 
 const (
@@ -15,6 +21,7 @@ const (
 	OutFormatCheckstyle        = "checkstyle"
 	OutFormatCodeClimate       = "code-climate"
 	OutFormatJunitXML          = "junit-xml"
+	OutFormatSarif             = "sarif"
 )
 
 var OutFormats = []string{
@@ -25,6 +32,7 @@ var OutFormats = []string{
 	OutFormatCheckstyle,
 	OutFormatCodeClimate,
 	OutFormatJunitXML,
+	OutFormatSarif,
 }
 
 type ExcludePattern struct {
@@ -103,11 +111,21 @@ type Run struct {
 	CPUProfilePath      string
 	MemProfilePath      string
 	TracePath           string
+	ProfileOutPath      string
 	Concurrency         int
-	PrintResourcesUsage bool `mapstructure:"print-resources-usage"`
-
-	Config   string
-	NoConfig bool
+	PrintResourcesUsage bool   `mapstructure:"print-resources-usage"`
+	MaxMemory           int    `mapstructure:"max-memory"` // in megabytes
+	UseDaemon           bool   `mapstructure:"daemon"`
+	DaemonSocket        string `mapstructure:"daemon-socket"`
+	StdinFilename       string `mapstructure:"stdin-filename"`
+	Watch               bool
+	Shard               string
+	ExplainLocation     string `mapstructure:"explain-location"`
+
+	Config         string
+	NoConfig       bool
+	UsedConfigFile string // a path to the read config file, it's set by the config reader
+	IsIncremental  bool   // it's set by servers analyzing only changed packages multiple times
 
 	Args []string
 
@@ -244,6 +262,9 @@ type DogsledSettings struct {
 }
 
 var defaultLintersSettings = LintersSettings{
+	Errcheck: ErrcheckSettings{
+		Ignore: "fmt:.*",
+	},
 	Lll: LllSettings{
 		LineLength: 120,
 		TabWidth:   1,
@@ -285,8 +306,22 @@ type ExcludeRule struct {
 	Path    string
 	Text    string
 	Source  string
+	RuleID  string `mapstructure:"rule-id"`
+
+	Package  string // a regexp of the package import path, e.g. x/y_test for external tests of x/y
+	Function string // a regexp of the name of the enclosing function: Func or Type.Method
+	// GeneratedBy is a regexp of header comments of generated files. Issues in
+	// files matching it aren't excluded as generated: only the rule applies.
+	// Files are found by the generated mode, by the lax one if it's disabled.
+	GeneratedBy string `mapstructure:"generated-by"`
+
+	Expires string // the rule doesn't apply after this date in ExpiresLayout
+	Owner   string
 }
 
+// ExpiresLayout is a layout of expiry dates of exclude rules and nolint directives.
+const ExpiresLayout = "2006-01-02"
+
 func validateOptionalRegex(value string) error {
 	if value == "" {
 		return nil
@@ -305,6 +340,23 @@ func (e ExcludeRule) Validate() error {
 	if err := validateOptionalRegex(e.Source); err != nil {
 		return fmt.Errorf("invalid source regex: %v", err)
 	}
+	if err := validateOptionalRegex(e.RuleID); err != nil {
+		return fmt.Errorf("invalid rule-id regex: %v", err)
+	}
+	if err := validateOptionalRegex(e.Package); err != nil {
+		return fmt.Errorf("invalid package regex: %v", err)
+	}
+	if err := validateOptionalRegex(e.Function); err != nil {
+		return fmt.Errorf("invalid function regex: %v", err)
+	}
+	if err := validateOptionalRegex(e.GeneratedBy); err != nil {
+		return fmt.Errorf("invalid generated-by regex: %v", err)
+	}
+	if e.Expires != "" {
+		if _, err := time.Parse(ExpiresLayout, e.Expires); err != nil {
+			return fmt.Errorf("invalid expires date %q: YYYY-MM-DD is expected", e.Expires)
+		}
+	}
 	nonBlank := 0
 	if len(e.Linters) > 0 {
 		nonBlank++
@@ -318,8 +370,21 @@ func (e ExcludeRule) Validate() error {
 	if e.Source != "" {
 		nonBlank++
 	}
+	if e.RuleID != "" {
+		nonBlank++
+	}
+	if e.Package != "" {
+		nonBlank++
+	}
+	if e.Function != "" {
+		nonBlank++
+	}
+	if e.GeneratedBy != "" {
+		nonBlank++
+	}
 	if nonBlank < 2 {
-		return errors.New("at least 2 of (text, source, path, linters) should be set")
+		return errors.New("at least 2 of (text, source, path, linters, rule-id, package, function, generated-by) " +
+			"should be set")
 	}
 	return nil
 }
@@ -329,16 +394,127 @@ type Issues struct {
 	ExcludeRules       []ExcludeRule `mapstructure:"exclude-rules"`
 	UseDefaultExcludes bool          `mapstructure:"exclude-use-default"`
 
+	FailOnUnusedExcludes bool `mapstructure:"fail-on-unused-excludes"`
+
 	MaxIssuesPerLinter int `mapstructure:"max-issues-per-linter"`
 	MaxSameIssues      int `mapstructure:"max-same-issues"`
 
 	DiffFromRevision  string `mapstructure:"new-from-rev"`
 	DiffPatchFilePath string `mapstructure:"new-from-patch"`
 	Diff              bool   `mapstructure:"new"`
+	DiffAffectedOnly  bool   `mapstructure:"new-affected-only"`
+	DiffStaged        bool   `mapstructure:"staged"`
+
+	ShowSuppressed bool `mapstructure:"show-suppressed"`
+
+	Generated Generated
 
 	NeedFix bool `mapstructure:"fix"`
 }
 
+const (
+	GeneratedModeStrict   = "strict"
+	GeneratedModeLax      = "lax"
+	GeneratedModeDisabled = "disabled"
+)
+
+// Validate checks exclude rules and generated files: the config reader and
+// the library API share it.
+func (i Issues) Validate() error {
+	for idx, rule := range i.ExcludeRules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("error in exclude rule #%d: %v", idx, err)
+		}
+	}
+	if err := i.Generated.Validate(); err != nil {
+		return fmt.Errorf("error in generated files config: %v", err)
+	}
+	return nil
+}
+
+// Generated configures detection of generated files: issues in them are excluded.
+type Generated struct {
+	// Mode is lax by default: it finds markers like "do not edit" in header comments,
+	// strict mode finds only "// Code generated ... DO NOT EDIT." comments
+	// by https://golang.org/s/generatedcode, disabled mode doesn't detect generated files.
+	Mode string
+
+	Headers []string // regexps of header comments of generated files
+	Files   []string // globs of names of generated files, e.g. *_mock.go
+}
+
+func (g Generated) Validate() error {
+	switch g.Mode {
+	case "", GeneratedModeStrict, GeneratedModeLax, GeneratedModeDisabled:
+	default:
+		return fmt.Errorf("invalid mode %q: must be %q, %q or %q",
+			g.Mode, GeneratedModeStrict, GeneratedModeLax, GeneratedModeDisabled)
+	}
+
+	for _, header := range g.Headers {
+		if _, err := regexp.Compile(header); err != nil {
+			return fmt.Errorf("invalid header regex %q: %v", header, err)
+		}
+	}
+	for _, glob := range g.Files {
+		if _, err := filepath.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid files glob %q: %v", glob, err)
+		}
+	}
+	return nil
+}
+
+const (
+	CustomLinterLoadModeFiles = "files"
+	CustomLinterLoadModeTypes = "types"
+)
+
+// CustomLinterSettings configures a linter loaded from outside of the binary:
+// a Go plugin (*.so) exporting `var Analyzers []*analysis.Analyzer` or
+// an executable speaking the JSON protocol over stdin and stdout.
+type CustomLinterSettings struct {
+	Path        string
+	Description string
+	Presets     []string
+	LoadMode    string `mapstructure:"load-mode"` // only for executables, Go plugins are always loaded for go/analysis
+	OriginalURL string `mapstructure:"original-url"`
+
+	// Settings are passed to executables as is and are flags of analyzers
+	// by analyzer names for Go plugins.
+	Settings map[string]interface{}
+}
+
+func (s CustomLinterSettings) IsPlugin() bool {
+	return strings.HasSuffix(s.Path, ".so")
+}
+
+func (s CustomLinterSettings) Validate() error {
+	if s.Path == "" {
+		return errors.New("path must be set")
+	}
+
+	switch s.LoadMode {
+	case "":
+	case CustomLinterLoadModeFiles, CustomLinterLoadModeTypes:
+		if s.IsPlugin() {
+			return errors.New("load-mode can't be set for Go plugins")
+		}
+	default:
+		return fmt.Errorf("invalid load-mode %q: must be %q or %q",
+			s.LoadMode, CustomLinterLoadModeFiles, CustomLinterLoadModeTypes)
+	}
+
+	if s.IsPlugin() {
+		for name, flags := range s.Settings {
+			if _, ok := flags.(map[string]interface{}); !ok {
+				return fmt.Errorf("settings of analyzer %q must be a map of its flags", name)
+			}
+		}
+	}
+
+	return nil
+}
+
 type Config struct { //nolint:maligned
 	Run Run
 
@@ -347,18 +523,47 @@ type Config struct { //nolint:maligned
 		Color               string
 		PrintIssuedLine     bool `mapstructure:"print-issued-lines"`
 		PrintLinterName     bool `mapstructure:"print-linter-name"`
+		PrintRuleID         bool `mapstructure:"print-rule-id"`
 		PrintWelcomeMessage bool `mapstructure:"print-welcome"`
 	}
 
 	LintersSettings LintersSettings `mapstructure:"linters-settings"`
 	Linters         Linters
+	CustomLinters   map[string]CustomLinterSettings `mapstructure:"custom-linters"`
 	Issues          Issues
 
 	InternalTest bool // Option is used only for testing golangci-lint code, don't use it
 }
 
+// NewDefault returns a config with default values of command-line options:
+// it's the config of 'golangci-lint run' without a config file and options.
 func NewDefault() *Config {
-	return &Config{
-		LintersSettings: defaultLintersSettings,
+	cfg := &Config{
+		LintersSettings: deepCopyValue(reflect.ValueOf(defaultLintersSettings)).Interface().(LintersSettings),
 	}
+
+	ls := &cfg.LintersSettings
+	ls.Golint.MinConfidence = 0.8
+	ls.Gofmt.Simplify = true
+	ls.Gocyclo.MinComplexity = 30
+	ls.Dupl.Threshold = 150
+	ls.Goconst.MinStringLen = 3
+	ls.Goconst.MinOccurrencesCount = 3
+
+	cfg.Output.Format = OutFormatColoredLineNumber
+	cfg.Output.Color = "auto"
+	cfg.Output.PrintIssuedLine = true
+	cfg.Output.PrintLinterName = true
+
+	cfg.Run.Concurrency = runtime.NumCPU()
+	cfg.Run.ExitCodeIfIssuesFound = exitcodes.IssuesFound
+	cfg.Run.Deadline = time.Minute
+	cfg.Run.AnalyzeTests = true
+	cfg.Run.UseDefaultSkipDirs = true
+
+	cfg.Issues.UseDefaultExcludes = true
+	cfg.Issues.MaxIssuesPerLinter = 50
+	cfg.Issues.MaxSameIssues = 3
+
+	return cfg
 }
@@ -0,0 +1,60 @@
+package config
+
+import "reflect"
+
+// DeepCopy returns a copy of the config sharing no maps, slices or pointers
+// with it: e.g. concurrent runs can change their copies of a config.
+func (c *Config) DeepCopy() *Config {
+	ret := deepCopyValue(reflect.ValueOf(c).Elem()).Interface().(Config)
+	return &ret
+}
+
+// deepCopyValue copies exported fields deeply, unexported fields are
+// copied shallowly: they are only replaced, not modified.
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		ret := reflect.New(v.Type().Elem())
+		ret.Elem().Set(deepCopyValue(v.Elem()))
+		return ret
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		ret := reflect.New(v.Type()).Elem()
+		ret.Set(deepCopyValue(v.Elem()))
+		return ret
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		ret := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return ret
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		ret := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, k := range v.MapKeys() {
+			ret.SetMapIndex(k, deepCopyValue(v.MapIndex(k)))
+		}
+		return ret
+	case reflect.Struct:
+		ret := reflect.New(v.Type()).Elem()
+		ret.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if f := ret.Field(i); f.CanSet() {
+				f.Set(deepCopyValue(v.Field(i)))
+			}
+		}
+		return ret
+	default:
+		return v
+	}
+}
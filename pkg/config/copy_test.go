@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeepCopy(t *testing.T) {
+	cfg := NewDefault()
+	cfg.Linters.Enable = []string{"gofmt"}
+	cfg.Issues.ExcludeRules = []ExcludeRule{{Linters: []string{"golint"}}}
+	cfg.LintersSettings.Govet.Settings = map[string]map[string]interface{}{
+		"printf": {"funcs": []interface{}{"Logf"}},
+	}
+
+	c := cfg.DeepCopy()
+	assert.Equal(t, cfg, c)
+
+	c.Linters.Enable[0] = "lll"
+	c.Issues.ExcludeRules[0].Linters[0] = "lll"
+	c.LintersSettings.Govet.Settings["printf"]["funcs"].([]interface{})[0] = "Errorf"
+	c.LintersSettings.Gocritic.SettingsPerCheck["check"] = GocriticCheckSettings{}
+
+	assert.Equal(t, []string{"gofmt"}, cfg.Linters.Enable)
+	assert.Equal(t, "golint", cfg.Issues.ExcludeRules[0].Linters[0])
+	assert.Equal(t, "Logf", cfg.LintersSettings.Govet.Settings["printf"]["funcs"].([]interface{})[0])
+	assert.Empty(t, cfg.LintersSettings.Gocritic.SettingsPerCheck)
+}
@@ -79,6 +79,15 @@ func (r *FileReader) parseConfig() error {
 	if err := r.validateConfig(); err != nil {
 		return fmt.Errorf("can't validate config: %s", err)
 	}
+	r.cfg.Run.UsedConfigFile = viper.ConfigFileUsed()
+
+	// paths of custom linters are relative to the config file
+	for name, s := range r.cfg.CustomLinters {
+		if s.Path != "" && !filepath.IsAbs(s.Path) {
+			s.Path = filepath.Join(filepath.Dir(viper.ConfigFileUsed()), s.Path)
+			r.cfg.CustomLinters[name] = s
+		}
+	}
 
 	if r.cfg.InternalTest { // just for testing purposes: to detect config file usage
 		fmt.Fprintln(logutils.StdOut, "test")
@@ -109,14 +118,17 @@ func (r *FileReader) validateConfig() error {
 	if c.Run.IsVerbose {
 		return errors.New("can't set run.verbose option with config: only on command-line")
 	}
-	for i, rule := range c.Issues.ExcludeRules {
-		if err := rule.Validate(); err != nil {
-			return fmt.Errorf("error in exclude rule #%d: %v", i, err)
-		}
+	if err := c.Issues.Validate(); err != nil {
+		return err
 	}
 	if err := c.LintersSettings.Govet.Validate(); err != nil {
 		return fmt.Errorf("error in govet config: %v", err)
 	}
+	for name, s := range c.CustomLinters {
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("error in custom linter %q: %v", name, err)
+		}
+	}
 	return nil
 }
 
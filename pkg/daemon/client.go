@@ -0,0 +1,48 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+type Client struct {
+	socketPath string
+}
+
+func NewClient(socketPath string) *Client {
+	return &Client{socketPath: socketPath}
+}
+
+// Do sends the request and waits for the response. An error in the response
+// is returned as an error.
+func (c Client) Do(ctx context.Context, req *Request) (*Response, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", c.socketPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't connect to server on %s (is `golangci-lint serve` running?)", c.socketPath)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, errors.Wrap(err, "failed to send request")
+	}
+
+	var resp Response
+	if err = json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, errors.Wrap(err, "failed to read response")
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,121 @@
+package daemon
+
+import (
+	"context"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newIssue(path string, line int) result.Issue {
+	return result.Issue{
+		FromLinter: "linter",
+		Text:       "text",
+		Pos:        token.Position{Filename: path, Line: line},
+	}
+}
+
+func TestIssuesStore(t *testing.T) {
+	s := NewIssuesStore()
+	s.Reset([]result.Issue{
+		newIssue("a/a.go", 2),
+		newIssue("a/a.go", 1),
+		newIssue("b/b.go", 1),
+	})
+	assert.Equal(t, 3, s.Count())
+	assert.Equal(t, []result.Issue{newIssue("a/a.go", 1), newIssue("a/a.go", 2)}, s.Issues([]string{"a"}))
+
+	dirA, err := filepath.Abs("a")
+	require.NoError(t, err)
+	s.Replace([]string{dirA}, []result.Issue{
+		newIssue("a/a.go", 3),
+		newIssue("b/b.go", 5), // b wasn't re-analyzed
+	})
+	assert.Equal(t, []result.Issue{
+		newIssue("a/a.go", 3),
+		newIssue("b/b.go", 1),
+	}, s.Issues(nil))
+	assert.Equal(t, []result.Issue{newIssue("b/b.go", 1)}, s.Issues([]string{"b/b.go"}))
+}
+
+type testHandler struct {
+	lintRequests chan *Request
+}
+
+func (h testHandler) Lint(_ context.Context, req *Request) (*Response, error) {
+	h.lintRequests <- req
+	return &Response{Issues: []result.Issue{newIssue("a.go", 1)}}, nil
+}
+
+func (h testHandler) Status() *Status {
+	return &Status{Runs: 1}
+}
+
+func TestServerClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "daemon")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socketPath := filepath.Join(dir, "test.sock")
+	h := testHandler{lintRequests: make(chan *Request, 1)}
+	srv := NewServer(socketPath, h, time.Minute, logutils.NewStderrLog("test"))
+
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(context.Background())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	c := NewClient(socketPath)
+	var resp *Response
+	for { // wait until the server starts listening
+		resp, err = c.Do(ctx, &Request{Command: CommandStatus})
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Status.Runs)
+
+	fi, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	resp, err = c.Do(ctx, &Request{Command: CommandLint, Files: []string{"a.go"}})
+	require.NoError(t, err)
+	assert.Equal(t, []result.Issue{newIssue("a.go", 1)}, resp.Issues)
+	assert.Equal(t, []string{"a.go"}, (<-h.lintRequests).Files)
+
+	_, err = c.Do(ctx, &Request{Command: "unknown"})
+	assert.Error(t, err)
+
+	_, err = c.Do(ctx, &Request{Command: CommandShutdown})
+	require.NoError(t, err)
+	require.NoError(t, <-served)
+}
+
+func TestDefaultSocketPath(t *testing.T) {
+	path, err := DefaultSocketPath(".")
+	require.NoError(t, err)
+
+	fi, err := os.Stat(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.True(t, fi.IsDir())
+	assert.Equal(t, os.FileMode(0700), fi.Mode().Perm())
+
+	otherPath, err := DefaultSocketPath("..")
+	require.NoError(t, err)
+	assert.NotEqual(t, path, otherPath)
+}
@@ -0,0 +1,21 @@
+// +build !windows
+
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+func checkOwner(fi os.FileInfo) error {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+
+	if int(st.Uid) != os.Getuid() {
+		return fmt.Errorf("is owned by another user with uid %d", st.Uid)
+	}
+	return nil
+}
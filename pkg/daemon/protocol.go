@@ -0,0 +1,85 @@
+// Package daemon implements a local server keeping golangci-lint state
+// warm between runs and a client for it. A client sends one JSON request
+// per connection over a Unix socket and reads one JSON response.
+package daemon
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	CommandLint     = "lint"
+	CommandStatus   = "status"
+	CommandShutdown = "shutdown"
+)
+
+type Request struct {
+	Command string
+
+	// Files limits returned issues to the given files or directories,
+	// all issues are returned if it's empty.
+	Files []string `json:",omitempty"`
+}
+
+type Response struct {
+	Issues []result.Issue `json:",omitempty"`
+	Report *report.Data   `json:",omitempty"`
+	Status *Status        `json:",omitempty"`
+	Error  string         `json:",omitempty"`
+}
+
+type Status struct {
+	Dir          string
+	PID          int
+	StartedAt    time.Time
+	Runs         int
+	TrackedFiles int
+	CachedIssues int
+	LastRun      *RunInfo `json:",omitempty"`
+}
+
+type RunInfo struct {
+	At       time.Time
+	Duration time.Duration
+	Args     []string // empty if nothing was re-analyzed
+}
+
+// DefaultSocketPath returns a socket path for the daemon serving the directory.
+// It's placed into the temp dir because Unix socket paths have a small length limit:
+// into a directory accessible only by the current user to not let other users
+// connect to the daemon or squat the socket path.
+func DefaultSocketPath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err == nil {
+		dir = absDir
+	}
+
+	socketsDir := filepath.Join(os.TempDir(), fmt.Sprintf("golangci-lint-%d", os.Getuid()))
+	if err = os.MkdirAll(socketsDir, 0700); err != nil {
+		return "", errors.Wrapf(err, "failed to create dir for sockets %s", socketsDir)
+	}
+
+	// Lstat: the dir could be created by another user before.
+	fi, err := os.Lstat(socketsDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "can't stat dir for sockets %s", socketsDir)
+	}
+	if !fi.IsDir() || fi.Mode().Perm()&0077 != 0 {
+		return "", fmt.Errorf("dir for sockets %s must be a directory accessible only by the current user", socketsDir)
+	}
+	if err = checkOwner(fi); err != nil {
+		return "", errors.Wrapf(err, "dir for sockets %s", socketsDir)
+	}
+
+	h := sha256.Sum256([]byte(dir))
+	return filepath.Join(socketsDir, fmt.Sprintf("%x.sock", h[:8])), nil
+}
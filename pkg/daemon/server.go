@@ -0,0 +1,147 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// Handler does the real work of the server. Methods can be called concurrently.
+type Handler interface {
+	Lint(ctx context.Context, req *Request) (*Response, error)
+	Status() *Status
+}
+
+type Server struct {
+	socketPath string
+	handler    Handler
+	log        logutils.Log
+	timeout    time.Duration
+
+	stopOnce sync.Once
+	stop     context.CancelFunc
+}
+
+// NewServer makes a server, timeout limits processing of every request.
+func NewServer(socketPath string, handler Handler, timeout time.Duration, log logutils.Log) *Server {
+	return &Server{
+		socketPath: socketPath,
+		handler:    handler,
+		timeout:    timeout,
+		log:        log,
+	}
+}
+
+func (s *Server) listen() (net.Listener, error) {
+	if _, err := os.Stat(s.socketPath); err == nil {
+		// The socket may be left by a crashed server.
+		if conn, dialErr := net.Dial("unix", s.socketPath); dialErr == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another server is already listening on %s", s.socketPath)
+		}
+		if err = os.Remove(s.socketPath); err != nil {
+			return nil, errors.Wrapf(err, "can't remove stale socket %s", s.socketPath)
+		}
+	}
+
+	l, err := net.Listen("unix", s.socketPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't listen on %s", s.socketPath)
+	}
+
+	// Only the current user can connect: the daemon reads and reports any source code.
+	if err = os.Chmod(s.socketPath, 0600); err != nil {
+		l.Close()
+		return nil, errors.Wrapf(err, "can't restrict access to %s", s.socketPath)
+	}
+
+	return l, nil
+}
+
+// Serve serves requests until ctx is done or a shutdown request is received.
+func (s *Server) Serve(ctx context.Context) error {
+	l, err := s.listen()
+	if err != nil {
+		return err
+	}
+
+	ctx, s.stop = context.WithCancel(ctx)
+	go func() {
+		<-ctx.Done()
+		l.Close() // also removes the socket file
+	}()
+
+	s.log.Infof("Listening on %s", s.socketPath)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "failed to accept connection")
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// Stop stops the server, it can be called from handlers.
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() {
+		if s.stop != nil {
+			s.stop()
+		}
+	})
+}
+
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	var req Request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		s.log.Warnf("Failed to decode request: %s", err)
+		return
+	}
+
+	resp := s.handle(ctx, &req)
+	if err := json.NewEncoder(conn).Encode(resp); err != nil {
+		s.log.Warnf("Failed to send response to %s request: %s", req.Command, err)
+	}
+}
+
+func (s *Server) handle(ctx context.Context, req *Request) *Response {
+	switch req.Command {
+	case CommandLint:
+		ctx, cancel := context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+
+		resp, err := s.handler.Lint(ctx, req)
+		if err != nil {
+			return &Response{Error: err.Error()}
+		}
+		return resp
+	case CommandStatus:
+		return &Response{Status: s.handler.Status()}
+	case CommandShutdown:
+		s.Stop()
+		return &Response{}
+	default:
+		return &Response{Error: fmt.Sprintf("unknown command %q", req.Command)}
+	}
+}
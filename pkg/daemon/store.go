@@ -0,0 +1,126 @@
+package daemon
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// IssuesStore keeps issues grouped by directories of their files: issues
+// of re-analyzed packages replace only their own issues.
+type IssuesStore struct {
+	mu    sync.Mutex
+	byDir map[string][]result.Issue
+}
+
+func NewIssuesStore() *IssuesStore {
+	return &IssuesStore{byDir: map[string][]result.Issue{}}
+}
+
+func issueDir(i *result.Issue) string {
+	path, err := filepath.Abs(i.FilePath())
+	if err != nil {
+		path = i.FilePath()
+	}
+	return filepath.Dir(path)
+}
+
+// Reset replaces all issues.
+func (s *IssuesStore) Reset(issues []result.Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.byDir = map[string][]result.Issue{}
+	for _, i := range issues {
+		dir := issueDir(&i)
+		s.byDir[dir] = append(s.byDir[dir], i)
+	}
+}
+
+// Replace replaces issues of the directories (absolute paths) by new issues.
+// New issues from other directories are ignored: they belong to packages
+// that weren't re-analyzed and already have their issues.
+func (s *IssuesStore) Replace(dirs []string, issues []result.Issue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replaced := map[string]bool{}
+	for _, dir := range dirs {
+		replaced[dir] = true
+		delete(s.byDir, dir)
+	}
+
+	for _, i := range issues {
+		dir := issueDir(&i)
+		if replaced[dir] {
+			s.byDir[dir] = append(s.byDir[dir], i)
+		}
+	}
+}
+
+func isPathMatched(path string, wantPaths []string) bool {
+	for _, wantPath := range wantPaths {
+		if path == wantPath || strings.HasPrefix(path, wantPath+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Issues returns sorted issues of the files or directories (recursively),
+// all issues are returned if paths are empty.
+func (s *IssuesStore) Issues(paths []string) []result.Issue {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var wantPaths []string
+	for _, p := range paths {
+		if absPath, err := filepath.Abs(p); err == nil {
+			p = absPath
+		}
+		wantPaths = append(wantPaths, p)
+	}
+
+	ret := []result.Issue{}
+	for _, dirIssues := range s.byDir {
+		for _, i := range dirIssues {
+			if len(wantPaths) != 0 {
+				absPath, err := filepath.Abs(i.FilePath())
+				if err != nil || !isPathMatched(absPath, wantPaths) {
+					continue
+				}
+			}
+			ret = append(ret, i)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		pi, pj := ret[i].Pos, ret[j].Pos
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		if pi.Column != pj.Column {
+			return pi.Column < pj.Column
+		}
+		return ret[i].FromLinter < ret[j].FromLinter
+	})
+	return ret
+}
+
+func (s *IssuesStore) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := 0
+	for _, dirIssues := range s.byDir {
+		n += len(dirIssues)
+	}
+	return n
+}
@@ -3,6 +3,7 @@ package fsutils
 import (
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	"sync"
 
 	"github.com/golangci/golangci-lint/pkg/logutils"
@@ -12,13 +13,57 @@ import (
 
 type FileCache struct {
 	files sync.Map
+
+	// overlay maps absolute file paths to contents replacing
+	// contents on disk, e.g. unsaved contents passed on stdin.
+	overlay map[string][]byte
 }
 
 func NewFileCache() *FileCache {
-	return &FileCache{}
+	return &FileCache{
+		overlay: map[string][]byte{},
+	}
+}
+
+// SetOverlay makes the content replace the file content on disk for all
+// users of the cache. It must be called before reading any files.
+func (fc *FileCache) SetOverlay(filePath string, content []byte) error {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "can't make abs path for %s", filePath)
+	}
+
+	fc.overlay[absPath] = content
+	return nil
+}
+
+// Overlay returns overlaid contents by absolute file paths in the format
+// of packages.Config.Overlay.
+func (fc *FileCache) Overlay() map[string][]byte {
+	return fc.overlay
+}
+
+// GetOverlay returns the overlaid content of the file if any: linters
+// reading files by name must lint this content instead.
+func (fc *FileCache) GetOverlay(filePath string) ([]byte, bool) {
+	if len(fc.overlay) == 0 {
+		return nil, false
+	}
+
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, false
+	}
+
+	content, ok := fc.overlay[absPath]
+	return content, ok
 }
 
 func (fc *FileCache) GetFileBytes(filePath string) ([]byte, error) {
+	if content, ok := fc.GetOverlay(filePath); ok {
+		return content, nil
+	}
+
 	cachedBytes, ok := fc.files.Load(filePath)
 	if ok {
 		return cachedBytes.([]byte), nil
@@ -33,7 +78,35 @@ func (fc *FileCache) GetFileBytes(filePath string) ([]byte, error) {
 	return fileBytes, nil
 }
 
-func prettifyBytesCount(n int) string {
+// Forget drops cached contents of the files given by absolute paths:
+// long-running servers call it for changed files.
+func (fc *FileCache) Forget(absPaths []string) {
+	forgetFiles(&fc.files, absPaths)
+}
+
+// forgetFiles deletes entries of the files from the map keyed by
+// relative or absolute file paths.
+func forgetFiles(files *sync.Map, absPaths []string) {
+	if len(absPaths) == 0 {
+		return
+	}
+
+	forgotten := map[string]bool{}
+	for _, p := range absPaths {
+		forgotten[p] = true
+	}
+
+	files.Range(func(key, _ interface{}) bool {
+		filePath := key.(string)
+		if absPath, err := filepath.Abs(filePath); err == nil && forgotten[absPath] {
+			files.Delete(key)
+		}
+		return true
+	})
+}
+
+// PrettifyBytesCount formats n bytes with a binary unit suffix.
+func PrettifyBytesCount(n int) string {
 	const (
 		Multiplexer = 1024
 		KiB         = 1 * Multiplexer
@@ -63,5 +136,5 @@ func (fc *FileCache) PrintStats(log logutils.Log) {
 		return true
 	})
 
-	log.Infof("File cache stats: %d entries of total size %s", mapLen, prettifyBytesCount(size))
+	log.Infof("File cache stats: %d entries of total size %s", mapLen, PrettifyBytesCount(size))
 }
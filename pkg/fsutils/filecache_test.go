@@ -0,0 +1,58 @@
+package fsutils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCacheOverlay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filecache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.go")
+	require.NoError(t, ioutil.WriteFile(path, []byte("package a\n"), os.ModePerm))
+
+	fc := NewFileCache()
+	require.NoError(t, fc.SetOverlay(path, []byte("package b\n\nvar x = 1\n")))
+	assert.Equal(t, map[string][]byte{path: []byte("package b\n\nvar x = 1\n")}, fc.Overlay())
+
+	content, err := fc.GetFileBytes(path)
+	require.NoError(t, err)
+	assert.Equal(t, "package b\n\nvar x = 1\n", string(content))
+
+	line, err := NewLineCache(fc).GetLine(path, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "var x = 1", line)
+}
+
+func TestFileCacheForget(t *testing.T) {
+	dir, err := ioutil.TempDir("", "filecache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.go")
+	require.NoError(t, ioutil.WriteFile(path, []byte("package a\n"), os.ModePerm))
+
+	fc := NewFileCache()
+	lc := NewLineCache(fc)
+	line, err := lc.GetLine(path, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "package a", line)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("package b\n"), os.ModePerm))
+	line, err = lc.GetLine(path, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "package a", line, "contents are cached until they are forgotten")
+
+	fc.Forget([]string{path})
+	lc.Forget([]string{path})
+	line, err = lc.GetLine(path, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "package b", line)
+}
@@ -52,6 +52,11 @@ func (lc *LineCache) getRawLine(filePath string, index0 int) ([]byte, error) {
 	return fc[index0], nil
 }
 
+// Forget drops cached lines of the files given by absolute paths.
+func (lc *LineCache) Forget(absPaths []string) {
+	forgetFiles(&lc.files, absPaths)
+}
+
 func (lc *LineCache) getFileCache(filePath string) (fileLinesCache, error) {
 	loadedFc, ok := lc.files.Load(filePath)
 	if ok {
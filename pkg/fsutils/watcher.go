@@ -0,0 +1,188 @@
+package fsutils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileChange is a change of a file found by Watcher.
+type FileChange struct {
+	Path string // absolute path
+	Kind FileChangeKind
+}
+
+type FileChangeKind int
+
+const (
+	FileCreated FileChangeKind = iota
+	FileModified
+	FileDeleted
+)
+
+func (k FileChangeKind) String() string {
+	switch k {
+	case FileCreated:
+		return "created"
+	case FileModified:
+		return "modified"
+	case FileDeleted:
+		return "deleted"
+	}
+	return "unknown"
+}
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher finds changes of files in a directory tree by polling: it
+// doesn't depend on OS-specific notification APIs and works on network
+// file systems and in containers. Only files accepted by the filter
+// are tracked; hidden directories and vendor are skipped.
+type Watcher struct {
+	root   string
+	filter func(path string) bool
+
+	pollMu sync.Mutex // serializes polls: a snapshot must not be replaced by an older one
+
+	mu    sync.Mutex
+	files map[string]fileState
+}
+
+// IsGoSourceFile is a Watcher filter accepting Go files and module files.
+func IsGoSourceFile(path string) bool {
+	switch filepath.Base(path) {
+	case "go.mod", "go.sum":
+		return true
+	}
+	return strings.HasSuffix(path, ".go")
+}
+
+// NewWatcher makes a watcher and takes the initial snapshot of files.
+func NewWatcher(root string, filter func(path string) bool) (*Watcher, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{
+		root:   root,
+		filter: filter,
+	}
+	if w.files, err = w.snapshot(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Files returns sorted paths of all tracked files.
+func (w *Watcher) Files() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ret := make([]string, 0, len(w.files))
+	for path := range w.files {
+		ret = append(ret, path)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func (w *Watcher) snapshot() (map[string]fileState, error) {
+	files := map[string]fileState{}
+	err := filepath.Walk(w.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) { // deleted during walking
+				return nil
+			}
+			return err
+		}
+
+		if info.IsDir() {
+			name := info.Name()
+			if path != w.root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") ||
+				name == "vendor" || name == "testdata" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if w.filter(path) {
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// Poll compares the current state of files with the previous one.
+// It can be called concurrently.
+func (w *Watcher) Poll() ([]FileChange, error) {
+	w.pollMu.Lock()
+	defer w.pollMu.Unlock()
+
+	files, err := w.snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var changes []FileChange
+	for path, st := range files {
+		prevSt, ok := w.files[path]
+		switch {
+		case !ok:
+			changes = append(changes, FileChange{Path: path, Kind: FileCreated})
+		case prevSt != st:
+			changes = append(changes, FileChange{Path: path, Kind: FileModified})
+		}
+	}
+	for path := range w.files {
+		if _, ok := files[path]; !ok {
+			changes = append(changes, FileChange{Path: path, Kind: FileDeleted})
+		}
+	}
+	w.files = files
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+// Watch polls every interval until ctx is done and calls onChange with
+// not empty lists of changes. Polling errors are passed to onError.
+func (w *Watcher) Watch(ctx context.Context, interval time.Duration,
+	onChange func(changes []FileChange), onError func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changes, err := w.Poll()
+			if err != nil {
+				onError(err)
+				continue
+			}
+			if len(changes) != 0 {
+				onChange(changes)
+			}
+		}
+	}
+}
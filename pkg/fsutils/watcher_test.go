@@ -0,0 +1,88 @@
+package fsutils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcherPoll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watcher")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), os.ModePerm))
+		return path
+	}
+
+	modified := write("a.go", "package a")
+	deleted := write("b.go", "package a")
+	write("README.md", "readme")
+	write("vendor/v/v.go", "package v")
+
+	w, err := NewWatcher(dir, IsGoSourceFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{modified, deleted}, w.Files())
+
+	changes, err := w.Poll()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	require.NoError(t, os.Chtimes(modified, time.Now(), time.Now().Add(time.Minute)))
+	require.NoError(t, os.Remove(deleted))
+	created := write("sub/c.go", "package sub")
+	write("README.md", "changed readme")
+
+	changes, err = w.Poll()
+	require.NoError(t, err)
+	assert.Equal(t, []FileChange{
+		{Path: modified, Kind: FileModified},
+		{Path: deleted, Kind: FileDeleted},
+		{Path: created, Kind: FileCreated},
+	}, changes)
+}
+
+func TestWatcherConcurrentPolls(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watcher")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.go")
+	require.NoError(t, ioutil.WriteFile(path, []byte("package a"), os.ModePerm))
+
+	w, err := NewWatcher(dir, IsGoSourceFile)
+	require.NoError(t, err)
+
+	changesCh := make(chan []FileChange, 1000)
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		go func() {
+			for j := 0; j < 50; j++ {
+				changes, err := w.Poll()
+				assert.NoError(t, err)
+				changesCh <- changes
+			}
+			done <- struct{}{}
+		}()
+	}
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+	close(changesCh)
+
+	changes, err := w.Poll()
+	require.NoError(t, err)
+	for c := range changesCh {
+		changes = append(changes, c...)
+	}
+	assert.Equal(t, []FileChange{{Path: path, Kind: FileModified}}, changes)
+}
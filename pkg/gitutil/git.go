@@ -0,0 +1,110 @@
+// Package gitutil runs git commands for modes working with the git index
+// and for blame of lines. All paths are relative to the current working directory.
+package gitutil
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+func run(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run 'git %s': %s",
+			strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+
+	return out, nil
+}
+
+func splitNullTerminated(out []byte) []string {
+	var ret []string
+	for _, p := range bytes.Split(out, []byte{0}) {
+		if len(p) != 0 {
+			ret = append(ret, string(p))
+		}
+	}
+	return ret
+}
+
+// StagedPatch returns the diff between HEAD and the index.
+func StagedPatch() ([]byte, error) {
+	return run("diff", "--cached", "--relative")
+}
+
+// StagedFiles returns files added, copied, modified or renamed in the index.
+func StagedFiles() ([]string, error) {
+	out, err := run("diff", "--cached", "--relative", "--name-only", "--diff-filter=ACMR", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	return splitNullTerminated(out), nil
+}
+
+// UnstagedFiles returns files changed in the working tree but not in the index.
+func UnstagedFiles() ([]string, error) {
+	out, err := run("diff", "--relative", "--name-only", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	return splitNullTerminated(out), nil
+}
+
+// UntrackedFiles returns files which aren't in the index and aren't ignored.
+func UntrackedFiles() ([]string, error) {
+	out, err := run("ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	return splitNullTerminated(out), nil
+}
+
+// IndexFileContent returns the content of the file in the index.
+func IndexFileContent(path string) ([]byte, error) {
+	return run("show", ":./"+filepath.ToSlash(path))
+}
+
+// Add adds current contents of the files to the index.
+func Add(paths []string) error {
+	_, err := run(append([]string{"add", "--"}, paths...)...)
+	return err
+}
+
+// BlameAuthors returns authors of lines of the file by line numbers starting from 1,
+// lines which aren't committed yet have the author "Not Committed Yet".
+func BlameAuthors(path string) (map[int]string, error) {
+	out, err := run("blame", "--line-porcelain", "--", path)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := map[int]string{}
+	line := 0
+	for _, s := range strings.Split(string(out), "\n") {
+		switch {
+		case strings.HasPrefix(s, "\t"): // the content of the line ends a header
+			line = 0
+		case line == 0:
+			// a header starts with "<sha> <original line> <final line> [<lines in group>]"
+			fields := strings.Fields(s)
+			if len(fields) >= 3 {
+				line, _ = strconv.Atoi(fields[2])
+			}
+		case strings.HasPrefix(s, "author "):
+			ret[line] = strings.TrimPrefix(s, "author ")
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,116 @@
+package gitutil
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initRepo(t *testing.T) (cleanup func()) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+
+	dir, err := ioutil.TempDir("", "gitutil")
+	require.NoError(t, err)
+
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	cleanup = func() {
+		os.Chdir(wd) //nolint:errcheck
+		os.RemoveAll(dir)
+	}
+
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "test"},
+	} {
+		if _, err = run(args...); err != nil {
+			cleanup()
+			require.NoError(t, err)
+		}
+	}
+
+	return cleanup
+}
+
+func TestIndex(t *testing.T) {
+	defer initRepo(t)()
+
+	require.NoError(t, ioutil.WriteFile("a.go", []byte("package a\n"), os.ModePerm))
+	require.NoError(t, ioutil.WriteFile("b.go", []byte("package a\n"), os.ModePerm))
+	require.NoError(t, Add([]string{"a.go", "b.go"}))
+	_, err := run("commit", "-q", "-m", "init")
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile("a.go", []byte("package a\n\nvar x = 1\n"), os.ModePerm))
+	require.NoError(t, Add([]string{"a.go"}))
+	require.NoError(t, ioutil.WriteFile("a.go", []byte("package a\n\nvar x = 2\n"), os.ModePerm))
+	require.NoError(t, ioutil.WriteFile("b.go", []byte("package a\n\nvar y = 1\n"), os.ModePerm))
+
+	staged, err := StagedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go"}, staged)
+
+	unstaged, err := UnstagedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "b.go"}, unstaged)
+
+	patch, err := StagedPatch()
+	require.NoError(t, err)
+	assert.Contains(t, string(patch), "+var x = 1")
+	assert.NotContains(t, string(patch), "var y")
+
+	authors, err := BlameAuthors("b.go")
+	require.NoError(t, err)
+	assert.Equal(t, map[int]string{1: "test", 2: "Not Committed Yet", 3: "Not Committed Yet"}, authors)
+}
+
+func TestUntrackedFilesAndIndexFileContent(t *testing.T) {
+	defer initRepo(t)()
+
+	require.NoError(t, os.Mkdir("sub", os.ModePerm))
+	for _, f := range []string{"sub/staged.go", "sub/deleted.go", "sub/removed.go"} {
+		require.NoError(t, ioutil.WriteFile(f, []byte("package sub\n"), os.ModePerm))
+	}
+	require.NoError(t, ioutil.WriteFile(".gitignore", []byte("ignored.go\n"), os.ModePerm))
+	require.NoError(t, Add([]string{"sub", ".gitignore"}))
+	_, err := run("commit", "-q", "-m", "init")
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile("sub/staged.go", []byte("package sub\n\nvar x = 1\n"), os.ModePerm))
+	require.NoError(t, Add([]string{"sub/staged.go"}))
+	require.NoError(t, ioutil.WriteFile("sub/staged.go", []byte("package sub\n\nvar x = 2\n"), os.ModePerm))
+	_, err = run("rm", "-q", "--cached", "sub/deleted.go")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove("sub/removed.go"))
+	require.NoError(t, ioutil.WriteFile("sub/untracked.go", []byte("package sub\n"), os.ModePerm))
+	require.NoError(t, ioutil.WriteFile("sub/ignored.go", []byte("package sub\n"), os.ModePerm))
+
+	require.NoError(t, os.Chdir("sub"))
+
+	// files deleted only in the index are untracked, ignored files aren't
+	untracked, err := UntrackedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deleted.go", "untracked.go"}, untracked)
+
+	unstaged, err := UnstagedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"removed.go", "staged.go"}, unstaged)
+
+	// paths are relative to the current directory
+	content, err := IndexFileContent("staged.go")
+	require.NoError(t, err)
+	assert.Equal(t, "package sub\n\nvar x = 1\n", string(content))
+
+	content, err = IndexFileContent(filepath.Join("..", "sub", "removed.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package sub\n", string(content))
+}
@@ -0,0 +1,36 @@
+package golangci
+
+import "sync"
+
+// runGlobals are settings of a run kept in process-wide variables.
+type runGlobals struct {
+	localPrefixes string
+}
+
+var (
+	globalsMu     sync.Mutex
+	globalsCond   = sync.NewCond(&globalsMu)
+	activeGlobals runGlobals
+	activeRuns    int
+)
+
+// acquireGlobals waits until runs with other globals finish and returns
+// a function to call when the run finishes.
+func acquireGlobals(g runGlobals) func() {
+	globalsMu.Lock()
+	for activeRuns != 0 && activeGlobals != g {
+		globalsCond.Wait()
+	}
+	activeGlobals = g
+	activeRuns++
+	globalsMu.Unlock()
+
+	return func() {
+		globalsMu.Lock()
+		activeRuns--
+		if activeRuns == 0 {
+			globalsCond.Broadcast()
+		}
+		globalsMu.Unlock()
+	}
+}
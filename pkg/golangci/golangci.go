@@ -0,0 +1,182 @@
+// Package golangci runs golangci-lint from Go code without its binary:
+// it loads packages, runs linters and processes issues like
+// 'golangci-lint run' does, but returns issues instead of printing them.
+package golangci
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/internal/pkgcache"
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
+	"github.com/golangci/golangci-lint/pkg/goutil"
+	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
+)
+
+// Result is a result of a run.
+type Result struct {
+	// Issues hidden by exclude patterns and rules, nolint directives etc. are
+	// returned with Suppression set only if the config has Issues.ShowSuppressed.
+	Issues []result.Issue
+	Report *report.Data
+}
+
+type options struct {
+	log     logutils.Log
+	linters []*linter.Config
+}
+
+// Option configures a run.
+type Option func(o *options)
+
+// WithLog sets the log of a run. By default messages are printed to stderr.
+// Fatalf and Panicf of the log are never called: Run returns their messages
+// as errors instead of exiting the process.
+func WithLog(log logutils.Log) Option {
+	return func(o *options) {
+		o.log = log
+	}
+}
+
+// WithLinters sets linters to run instead of linters enabled by the config.
+func WithLinters(linters []*linter.Config) Option {
+	return func(o *options) {
+		o.linters = linters
+	}
+}
+
+// NewDefaultConfig returns a config with the same default values as
+// 'golangci-lint run' has without a config file and options.
+func NewDefaultConfig() *config.Config {
+	return config.NewDefault()
+}
+
+// Run analyzes packages matching patterns with the config. The config isn't
+// modified and can be shared by concurrent runs. Issues fixed by --fix
+// aren't returned as with 'golangci-lint run'.
+func Run(ctx context.Context, cfg *config.Config, patterns []string, opts ...Option) (res *Result, err error) {
+	o := options{
+		log: logutils.NewStderrLog(""),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			fe, ok := p.(*fatalError)
+			if !ok {
+				panic(p)
+			}
+			res, err = nil, fe.err
+		}
+	}()
+
+	runCfg := cfg.DeepCopy()
+	runCfg.Run.Args = patterns
+	if runCfg.Run.Concurrency <= 0 {
+		runCfg.Run.Concurrency = runtime.NumCPU()
+	}
+
+	// goimports keeps local prefixes in a process-wide variable: only runs
+	// with the same ones are concurrent.
+	releaseGlobals := acquireGlobals(runGlobals{
+		localPrefixes: runCfg.LintersSettings.Goimports.LocalPrefixes,
+	})
+	defer releaseGlobals()
+
+	res = &Result{Report: &report.Data{}}
+	log := report.NewLogWrapper(errorLog{o.log}, res.Report)
+
+	if err = runCfg.Issues.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid issues settings")
+	}
+
+	runCfg.LintersSettings.Gocritic.InferEnabledChecks(log)
+	if err = runCfg.LintersSettings.Gocritic.Validate(log); err != nil {
+		return nil, errors.Wrap(err, "invalid gocritic settings")
+	}
+
+	dbManager := lintersdb.NewManager(runCfg)
+	enabledLinters := o.linters
+	if enabledLinters == nil {
+		enabledLinters, err = lintersdb.NewEnabledSet(dbManager, lintersdb.NewValidator(dbManager),
+			log.Child("lintersdb"), runCfg).Get(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, lc := range dbManager.GetAllSupportedLinterConfigs() {
+		isEnabled := false
+		for _, enabledLC := range enabledLinters {
+			if enabledLC.Name() == lc.Name() {
+				isEnabled = true
+				break
+			}
+		}
+		res.Report.AddLinter(lc.Name(), isEnabled, lc.EnabledByDefault)
+	}
+
+	issues, err := runLinters(ctx, runCfg, log, dbManager, enabledLinters)
+	if err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, errors.Wrap(ctx.Err(), "analysis was interrupted")
+	}
+
+	res.Issues = issues
+	return res, nil
+}
+
+func runLinters(ctx context.Context, cfg *config.Config, log logutils.Log, dbManager *lintersdb.Manager,
+	enabledLinters []*linter.Config) ([]result.Issue, error) {
+	goenv := goutil.NewEnv(log.Child("goenv"))
+	if err := goenv.Discover(ctx); err != nil {
+		log.Warnf("Failed to discover go env: %s", err)
+	}
+
+	fileCache := fsutils.NewFileCache()
+	lineCache := fsutils.NewLineCache(fileCache)
+	pkgCache, err := pkgcache.NewCache(timeutils.NewStopwatch("pkgcache", log.Child("stopwatch")),
+		log.Child("pkgcache"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build packages cache")
+	}
+
+	contextLoader := lint.NewContextLoader(cfg, log.Child("loader"), goenv, lineCache, fileCache, pkgCache,
+		load.NewGuard(), timeutils.NewProfiler())
+	lintCtx, err := contextLoader.Load(ctx, enabledLinters)
+	if err == lint.ErrNoAffectedPackages || err == lint.ErrEmptyShard {
+		log.Infof("Nothing to analyze: %s", err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "context loading failed")
+	}
+	lintCtx.Log = log.Child("linters context")
+
+	runner, err := lint.NewRunner(lintCtx.ASTCache, cfg, log.Child("runner"), goenv, lineCache, dbManager)
+	if err != nil {
+		return nil, err
+	}
+
+	issuesCh := processors.NewFixer(cfg, log, fileCache).Process(runner.Run(ctx, enabledLinters, lintCtx))
+	var issues []result.Issue
+	for i := range issuesCh {
+		issues = append(issues, i)
+	}
+	return issues, nil
+}
@@ -0,0 +1,104 @@
+package golangci
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestRun(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Linters.DisableAll = true
+	cfg.Linters.Enable = []string{"gofmt"}
+	cfg.Issues.ExcludeRules = []config.ExcludeRule{{Linters: []string{"golint"}, Path: `_test\.go$`}}
+
+	var wg sync.WaitGroup
+	results := make([]*Result, 2)
+	errs := make([]error, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = Run(context.Background(), cfg, []string{"./testdata/unformatted"})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, res := range results {
+		require.NoError(t, errs[i])
+		require.Len(t, res.Issues, 1)
+		assert.Equal(t, "gofmt", res.Issues[0].FromLinter)
+		assert.Equal(t, "testdata/unformatted/a.go", res.Issues[0].FilePath())
+
+		var enabled []string
+		for _, ld := range res.Report.Linters {
+			if ld.Enabled {
+				enabled = append(enabled, ld.Name)
+			}
+		}
+		assert.Equal(t, []string{"gofmt"}, enabled)
+	}
+	assert.Empty(t, cfg.Run.Args)
+	assert.Equal(t, []string{"gofmt"}, cfg.Linters.Enable)
+	assert.Equal(t, []config.ExcludeRule{{Linters: []string{"golint"}, Path: `_test\.go$`}}, cfg.Issues.ExcludeRules)
+}
+
+func TestRunInvalidExcludeRule(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Issues.ExcludeRules = []config.ExcludeRule{{Linters: []string{"golint"}, GeneratedBy: "("}}
+
+	_, err := Run(context.Background(), cfg, []string{"./testdata/unformatted"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid generated-by regex")
+}
+
+func TestRunInvalidCustomLinter(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.CustomLinters = map[string]config.CustomLinterSettings{
+		"mylinter": {Path: "mylinter.so", Settings: map[string]interface{}{"analyzer": 1}},
+	}
+
+	_, err := Run(context.Background(), cfg, []string{"./testdata/unformatted"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid custom linter "mylinter"`)
+}
+
+func TestErrorLogDoesNotExit(t *testing.T) {
+	log := errorLog{logutils.NewStderrLog("test")}.Child("child")
+
+	defer func() {
+		fe, ok := recover().(*fatalError)
+		require.True(t, ok)
+		assert.EqualError(t, fe.err, "can't do it: 1")
+	}()
+	log.Fatalf("can't do it: %d", 1)
+}
+
+func TestAcquireGlobals(t *testing.T) {
+	g1, g2 := runGlobals{localPrefixes: "a"}, runGlobals{localPrefixes: "b"}
+
+	release1 := acquireGlobals(g1)
+	release2 := acquireGlobals(g1) // runs with the same globals are concurrent
+
+	acquired := make(chan func())
+	go func() {
+		acquired <- acquireGlobals(g2)
+	}()
+
+	release1()
+	select {
+	case <-acquired:
+		t.Fatal("globals were changed during a run")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release2()
+	(<-acquired)()
+}
@@ -0,0 +1,31 @@
+package golangci
+
+import (
+	"fmt"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// fatalError is a panic value of errorLog recovered by Run.
+type fatalError struct {
+	err error
+}
+
+// errorLog makes Fatalf and Panicf of the wrapped log panic with *fatalError
+// instead of exiting the process: Run returns it as an error. Panics in
+// goroutines of linters are recovered by the runner and become errors too.
+type errorLog struct {
+	logutils.Log
+}
+
+func (l errorLog) Fatalf(format string, args ...interface{}) {
+	panic(&fatalError{err: fmt.Errorf(format, args...)})
+}
+
+func (l errorLog) Panicf(format string, args ...interface{}) {
+	panic(&fatalError{err: fmt.Errorf(format, args...)})
+}
+
+func (l errorLog) Child(name string) logutils.Log {
+	return errorLog{l.Log.Child(name)}
+}
@@ -0,0 +1,165 @@
+package golinters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"plugin"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// NewCustomPlugin loads analyzers from a Go plugin exporting
+// `var Analyzers []*analysis.Analyzer`. The plugin must be built by the same
+// Go version and with the same versions of dependencies as golangci-lint.
+func NewCustomPlugin(name string, settings *config.CustomLinterSettings) (*goanalysis.Linter, error) {
+	cfg := map[string]map[string]interface{}{}
+	for analyzerName, flags := range settings.Settings {
+		analyzerCfg, ok := flags.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("settings of analyzer %q must be a map of its flags, got %T", analyzerName, flags)
+		}
+		cfg[analyzerName] = analyzerCfg
+	}
+
+	analyzers, err := loadPluginAnalyzers(settings.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return goanalysis.NewLinter(name, settings.Description, analyzers, cfg), nil
+}
+
+type loadedPlugin struct {
+	analyzers []*analysis.Analyzer
+	err       error
+}
+
+// loadedPlugins caches plugins by paths: a linters manager is made for every
+// run of the library API, serve and lsp commands.
+var (
+	loadedPlugins   = map[string]loadedPlugin{}
+	loadedPluginsMu sync.Mutex
+)
+
+func loadPluginAnalyzers(path string) ([]*analysis.Analyzer, error) {
+	loadedPluginsMu.Lock()
+	defer loadedPluginsMu.Unlock()
+
+	if p, ok := loadedPlugins[path]; ok {
+		return p.analyzers, p.err
+	}
+
+	analyzers, err := openPluginAnalyzers(path)
+	loadedPlugins[path] = loadedPlugin{analyzers: analyzers, err: err}
+	return analyzers, err
+}
+
+func openPluginAnalyzers(path string) ([]*analysis.Analyzer, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open plugin %s", path)
+	}
+
+	sym, err := p.Lookup("Analyzers")
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s doesn't export Analyzers", path)
+	}
+
+	analyzers, ok := sym.(*[]*analysis.Analyzer)
+	if !ok {
+		return nil, fmt.Errorf("Analyzers of plugin %s has type %T instead of []*analysis.Analyzer",
+			path, sym)
+	}
+
+	return *analyzers, nil
+}
+
+// CustomExecutableRequest is written by golangci-lint to stdin of
+// an executable of a custom linter.
+type CustomExecutableRequest struct {
+	Settings map[string]interface{}
+	Packages []CustomExecutablePackage
+}
+
+type CustomExecutablePackage struct {
+	PkgPath string
+	GoFiles []string // absolute paths
+}
+
+// CustomExecutableResponse must be written by an executable of a custom
+// linter to stdout. Only Text and Pos of issues are required.
+type CustomExecutableResponse struct {
+	Issues []result.Issue
+}
+
+// CustomExecutable runs an executable for all analyzed packages at once:
+// the executable reads a CustomExecutableRequest in JSON from stdin and
+// writes a CustomExecutableResponse in JSON to stdout. A non-zero exit code
+// means a failure of the linter, not found issues.
+type CustomExecutable struct {
+	name     string
+	settings *config.CustomLinterSettings
+}
+
+func NewCustomExecutable(name string, settings *config.CustomLinterSettings) *CustomExecutable {
+	return &CustomExecutable{name: name, settings: settings}
+}
+
+func (ce CustomExecutable) Name() string {
+	return ce.name
+}
+
+func (ce CustomExecutable) Desc() string {
+	return ce.settings.Description
+}
+
+func (ce CustomExecutable) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+	req := CustomExecutableRequest{
+		Settings: ce.settings.Settings,
+	}
+	for _, pkg := range lintCtx.Packages {
+		req.Packages = append(req.Packages, CustomExecutablePackage{
+			PkgPath: pkg.PkgPath,
+			GoFiles: pkg.GoFiles,
+		})
+	}
+
+	reqData, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal request")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, ce.settings.Path)
+	cmd.Stdin = bytes.NewReader(reqData)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "failed to run %s: %s", ce.settings.Path, strings.TrimSpace(stderr.String()))
+	}
+
+	var resp CustomExecutableResponse
+	if err = json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal output of %s", ce.settings.Path)
+	}
+
+	for i := range resp.Issues {
+		if resp.Issues[i].Pos.Filename == "" || resp.Issues[i].Text == "" {
+			return nil, fmt.Errorf("%s returned issue #%d without position filename or text", ce.settings.Path, i)
+		}
+		resp.Issues[i].FromLinter = ce.name
+	}
+
+	return resp.Issues, nil
+}
@@ -0,0 +1,81 @@
+package golinters
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+)
+
+func TestCustomExecutable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts aren't supported")
+	}
+
+	dir, err := ioutil.TempDir("", "custom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// The script checks the request and reports an issue in the first file.
+	script := `#!/bin/sh
+req=$(cat)
+case "$req" in
+*'"Settings":{"max":1}'*'"PkgPath":"a","GoFiles":["/a/a.go"]'*) ;;
+*) echo "unexpected request: $req" >&2; exit 1 ;;
+esac
+echo '{"Issues":[{"Text":"bad","Pos":{"Filename":"/a/a.go","Line":3}}]}'
+`
+	path := filepath.Join(dir, "linter")
+	require.NoError(t, ioutil.WriteFile(path, []byte(script), 0755))
+
+	lnt := NewCustomExecutable("mylinter", &config.CustomLinterSettings{
+		Path:     path,
+		Settings: map[string]interface{}{"max": 1},
+	})
+	issues, err := lnt.Run(context.Background(), &linter.Context{
+		Packages: []*packages.Package{{PkgPath: "a", GoFiles: []string{"/a/a.go"}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "mylinter", issues[0].FromLinter)
+	assert.Equal(t, "bad", issues[0].Text)
+	assert.Equal(t, "/a/a.go", issues[0].FilePath())
+	assert.Equal(t, 3, issues[0].Line())
+
+	_, err = lnt.Run(context.Background(), &linter.Context{})
+	assert.Error(t, err)
+}
+
+func TestCustomPluginIsLoadedOnce(t *testing.T) {
+	path := filepath.Join(os.TempDir(), "no-such-custom-linter.so")
+	settings := &config.CustomLinterSettings{Path: path}
+
+	_, err := NewCustomPlugin("mylinter", settings)
+	require.Error(t, err)
+	_, ok := loadedPlugins[path]
+	assert.True(t, ok)
+
+	_, cachedErr := NewCustomPlugin("mylinter", settings)
+	assert.Equal(t, err, cachedErr)
+}
+
+func TestCustomPluginInvalidSettings(t *testing.T) {
+	path := filepath.Join(os.TempDir(), "invalid-settings-custom-linter.so")
+	_, err := NewCustomPlugin("mylinter", &config.CustomLinterSettings{
+		Path:     path,
+		Settings: map[string]interface{}{"analyzer": 1},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `settings of analyzer "analyzer"`)
+	_, ok := loadedPlugins[path]
+	assert.False(t, ok) // the plugin isn't opened
+}
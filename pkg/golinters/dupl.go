@@ -24,7 +24,13 @@ func (Dupl) Desc() string {
 }
 
 func (d Dupl) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
-	issues, err := duplAPI.Run(getAllFileNames(lintCtx), lintCtx.Settings().Dupl.Threshold)
+	files, copies, err := copyOverlaidFiles(lintCtx.FileCache, getAllFileNames(lintCtx))
+	if err != nil {
+		return nil, err
+	}
+	defer copies.remove()
+
+	issues, err := duplAPI.Run(files, lintCtx.Settings().Dupl.Threshold)
 	if err != nil {
 		return nil, err
 	}
@@ -35,9 +41,10 @@ func (d Dupl) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue,
 
 	res := make([]result.Issue, 0, len(issues))
 	for _, i := range issues {
-		toFilename, err := fsutils.ShortestRelPath(i.To.Filename(), "")
+		fromFile, toFile := copies.origName(i.From.Filename()), copies.origName(i.To.Filename())
+		toFilename, err := fsutils.ShortestRelPath(toFile, "")
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get shortest rel path for %q", i.To.Filename())
+			return nil, errors.Wrapf(err, "failed to get shortest rel path for %q", toFile)
 		}
 		dupl := fmt.Sprintf("%s:%d-%d", toFilename, i.To.LineStart(), i.To.LineEnd())
 		text := fmt.Sprintf("%d-%d lines are duplicate of %s",
@@ -45,13 +52,20 @@ func (d Dupl) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue,
 			formatCode(dupl, lintCtx.Cfg))
 		res = append(res, result.Issue{
 			Pos: token.Position{
-				Filename: i.From.Filename(),
+				Filename: fromFile,
 				Line:     i.From.LineStart(),
 			},
 			LineRange: &result.Range{
 				From: i.From.LineStart(),
 				To:   i.From.LineEnd(),
 			},
+			Related: []result.RelatedLocation{{
+				Pos: token.Position{
+					Filename: toFile,
+					Line:     i.To.LineStart(),
+				},
+				Message: fmt.Sprintf("%d-%d lines are duplicate", i.To.LineStart(), i.To.LineEnd()),
+			}},
 			Text:       text,
 			FromLinter: d.Name(),
 		})
@@ -11,6 +11,7 @@ import (
 
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
 )
 
 type Linter struct {
@@ -39,6 +40,10 @@ func (lnt Linter) allAnalyzerNames() []string {
 	return ret
 }
 
+func (lnt Linter) RuleIDs() []string {
+	return lnt.allAnalyzerNames()
+}
+
 func allFlagNames(fs *flag.FlagSet) []string {
 	var ret []string
 	fs.VisitAll(func(f *flag.Flag) {
@@ -114,7 +119,8 @@ func (lnt Linter) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Is
 		return nil, errors.Wrap(err, "failed to configure analyzers")
 	}
 
-	runner := newRunner(lnt.name, lintCtx.Log.Child("goanalysis"), lintCtx.PkgCache, lintCtx.LoadGuard, lintCtx.NeedWholeProgram)
+	runner := newRunner(lnt.name, lintCtx.Log.Child("goanalysis"), lintCtx.PkgCache, lintCtx.LoadGuard, lintCtx.NeedWholeProgram,
+		lintCtx.Profiler, timeutils.WorkerID(ctx), lintCtx.FileCache.Overlay())
 
 	diags, errs := runner.run(lnt.analyzers, lintCtx.Packages)
 	// Don't print all errs: they can duplicate.
@@ -128,7 +134,9 @@ func (lnt Linter) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Is
 		issues = append(issues, result.Issue{
 			FromLinter: lnt.Name(),
 			Text:       fmt.Sprintf("%s: %s", diag.Analyzer.Name, diag.Message),
+			RuleID:     diag.Analyzer.Name,
 			Pos:        diag.Position,
+			Related:    diag.Related,
 		})
 	}
 
@@ -150,3 +158,34 @@ func (lnt Linter) AnalyzerToLinterNameMapping() map[*analysis.Analyzer]string {
 	}
 	return ret
 }
+
+// VetToolAnalyzers returns configured analyzers of go/analysis linters to
+// run them as a vet tool by the go command, every analyzer is returned once.
+func VetToolAnalyzers(lcs []*linter.Config) ([]*analysis.Analyzer, error) {
+	var linters []*Linter
+	for _, lc := range lcs {
+		switch lnt := lc.Linter.(type) {
+		case *MetaLinter:
+			linters = append(linters, lnt.linters...)
+		case SupportedLinter:
+			linters = append(linters, NewLinter(lc.Name(), "", lnt.Analyzers(), lnt.Cfg()))
+		}
+	}
+
+	var ret []*analysis.Analyzer
+	seen := map[*analysis.Analyzer]bool{}
+	for _, lnt := range linters {
+		if err := lnt.configure(); err != nil {
+			return nil, errors.Wrapf(err, "failed to configure analyzers of %s", lnt.Name())
+		}
+
+		for _, a := range lnt.analyzers {
+			if !seen[a] {
+				seen[a] = true
+				ret = append(ret, a)
+			}
+		}
+	}
+
+	return ret, nil
+}
@@ -10,11 +10,14 @@ type Guard struct {
 	loadMutexes        map[*packages.Package]*sync.Mutex
 	mutexForExportData sync.Mutex
 	mutex              sync.Mutex
+
+	memory *memoryLimiter
 }
 
 func NewGuard() *Guard {
 	return &Guard{
 		loadMutexes: map[*packages.Package]*sync.Mutex{},
+		memory:      newMemoryLimiter(),
 	}
 }
 
@@ -22,6 +25,12 @@ func (g *Guard) AddMutexForPkg(pkg *packages.Package) {
 	g.loadMutexes[pkg] = &sync.Mutex{}
 }
 
+// ForgetPackages drops mutexes of packages loaded before: long-running
+// servers load packages again for every analysis.
+func (g *Guard) ForgetPackages() {
+	g.loadMutexes = map[*packages.Package]*sync.Mutex{}
+}
+
 func (g *Guard) MutexForPkg(pkg *packages.Package) *sync.Mutex {
 	return g.loadMutexes[pkg]
 }
@@ -33,3 +42,29 @@ func (g *Guard) MutexForExportData() *sync.Mutex {
 func (g *Guard) Mutex() *sync.Mutex {
 	return &g.mutex
 }
+
+// SetMemoryLimit sets a soft limit of heap usage in bytes, 0 means no limit.
+func (g *Guard) SetMemoryLimit(limit uint64) {
+	g.memory.setLimit(limit)
+}
+
+// IsUnderMemoryPressure returns true if heap usage exceeds the memory limit.
+func (g *Guard) IsUnderMemoryPressure() bool {
+	return g.memory.isUnderPressure()
+}
+
+// AcquireMemory blocks until heap usage drops below the memory limit or
+// until there are no other packages being loaded or analyzed.
+// Every call must be paired with ReleaseMemory.
+func (g *Guard) AcquireMemory() {
+	g.memory.acquire()
+}
+
+func (g *Guard) ReleaseMemory() {
+	g.memory.release()
+}
+
+// ReportMemoryPressureOnce runs report only on the first call.
+func (g *Guard) ReportMemoryPressureOnce(report func()) {
+	g.memory.reportOnce.Do(report)
+}
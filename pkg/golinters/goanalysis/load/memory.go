@@ -0,0 +1,107 @@
+package load
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// Reading of memory stats stops the world: don't do it too often.
+	memStatsTTL = 20 * time.Millisecond
+
+	memoryWaitInterval = 50 * time.Millisecond
+)
+
+type memoryLimiter struct {
+	limit  uint64 // bytes, 0 means no limit
+	active int32  // number of packages holding memory
+
+	mu             sync.Mutex
+	heapInUse      uint64
+	heapCheckedAt  time.Time
+	lastFreeMemory time.Time
+
+	reportOnce sync.Once
+}
+
+func newMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{}
+}
+
+func (l *memoryLimiter) setLimit(limit uint64) {
+	atomic.StoreUint64(&l.limit, limit)
+}
+
+func (l *memoryLimiter) getHeapInUse() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if time.Since(l.heapCheckedAt) > memStatsTTL {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		l.heapInUse = ms.HeapAlloc
+		l.heapCheckedAt = time.Now()
+	}
+
+	return l.heapInUse
+}
+
+func (l *memoryLimiter) isUnderPressure() bool {
+	limit := atomic.LoadUint64(&l.limit)
+	if limit == 0 {
+		return false
+	}
+
+	return l.getHeapInUse() >= limit
+}
+
+// freeMemory forces garbage collection: by default GC is triggered only when heap
+// doubles, it's too late when we are near the limit.
+func (l *memoryLimiter) freeMemory() {
+	l.mu.Lock()
+	if time.Since(l.lastFreeMemory) < memoryWaitInterval {
+		l.mu.Unlock()
+		return
+	}
+	l.lastFreeMemory = time.Now()
+	l.mu.Unlock()
+
+	debug.FreeOSMemory()
+
+	l.mu.Lock()
+	l.heapCheckedAt = time.Time{} // invalidate cached heap usage
+	l.mu.Unlock()
+}
+
+func (l *memoryLimiter) acquire() {
+	if atomic.LoadUint64(&l.limit) == 0 {
+		return
+	}
+
+	for l.isUnderPressure() {
+		l.freeMemory()
+		if !l.isUnderPressure() {
+			break
+		}
+
+		// Nobody will free memory for us: continue with one package at a time.
+		if atomic.LoadInt32(&l.active) == 0 {
+			break
+		}
+
+		time.Sleep(memoryWaitInterval)
+	}
+
+	atomic.AddInt32(&l.active, 1)
+}
+
+func (l *memoryLimiter) release() {
+	if atomic.LoadUint64(&l.limit) == 0 {
+		return
+	}
+
+	atomic.AddInt32(&l.active, -1)
+}
@@ -0,0 +1,63 @@
+package load
+
+import (
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryLimiterWithoutLimit(t *testing.T) {
+	l := newMemoryLimiter()
+	assert.False(t, l.isUnderPressure())
+
+	l.acquire()
+	assert.EqualValues(t, 0, atomic.LoadInt32(&l.active))
+	l.release()
+	assert.EqualValues(t, 0, atomic.LoadInt32(&l.active))
+}
+
+func TestMemoryLimiterUnderLimit(t *testing.T) {
+	l := newMemoryLimiter()
+	l.setLimit(math.MaxUint64)
+	assert.False(t, l.isUnderPressure())
+
+	l.acquire()
+	l.acquire()
+	assert.EqualValues(t, 2, atomic.LoadInt32(&l.active))
+	l.release()
+	l.release()
+	assert.EqualValues(t, 0, atomic.LoadInt32(&l.active))
+}
+
+func TestMemoryLimiterOverLimit(t *testing.T) {
+	l := newMemoryLimiter()
+	l.setLimit(1)
+	assert.True(t, l.isUnderPressure())
+
+	// Nobody else holds memory: the first package isn't blocked.
+	l.acquire()
+
+	acquired := make(chan struct{})
+	go func() {
+		l.acquire()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("the second package must wait until the first one releases memory")
+	case <-time.After(3 * memoryWaitInterval):
+	}
+
+	l.release()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("the second package must continue after the first one released memory")
+	}
+	l.release()
+	assert.EqualValues(t, 0, atomic.LoadInt32(&l.active))
+}
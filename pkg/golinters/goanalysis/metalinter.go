@@ -9,6 +9,7 @@ import (
 
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
 )
 
 type MetaLinter struct {
@@ -28,6 +29,17 @@ func (ml MetaLinter) Desc() string {
 	return ""
 }
 
+func (ml MetaLinter) ChildLinterNames() []string {
+	var ret []string
+	for _, linter := range ml.linters {
+		ret = append(ret, linter.Name())
+	}
+	for _, name := range ml.analyzerToLinterName {
+		ret = append(ret, name)
+	}
+	return ret
+}
+
 func (ml MetaLinter) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
 	for _, linter := range ml.linters {
 		if err := analysis.Validate(linter.analyzers); err != nil {
@@ -46,7 +58,8 @@ func (ml MetaLinter) Run(ctx context.Context, lintCtx *linter.Context) ([]result
 		allAnalyzers = append(allAnalyzers, linter.analyzers...)
 	}
 
-	runner := newRunner("metalinter", lintCtx.Log.Child("goanalysis"), lintCtx.PkgCache, lintCtx.LoadGuard, lintCtx.NeedWholeProgram)
+	runner := newRunner("metalinter", lintCtx.Log.Child("goanalysis"), lintCtx.PkgCache, lintCtx.LoadGuard, lintCtx.NeedWholeProgram,
+		lintCtx.Profiler, timeutils.WorkerID(ctx), lintCtx.FileCache.Overlay())
 
 	diags, errs := runner.run(allAnalyzers, lintCtx.Packages)
 	// Don't print all errs: they can duplicate.
@@ -60,7 +73,9 @@ func (ml MetaLinter) Run(ctx context.Context, lintCtx *linter.Context) ([]result
 		issues = append(issues, result.Issue{
 			FromLinter: ml.analyzerToLinterName[diag.Analyzer],
 			Text:       fmt.Sprintf("%s: %s", diag.Analyzer, diag.Message),
+			RuleID:     diag.Analyzer.Name,
 			Pos:        diag.Position,
+			Related:    diag.Related,
 		})
 	}
 
@@ -0,0 +1,47 @@
+package goanalysis
+
+import (
+	"fmt"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/shadow"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func (act *action) report(d analysis.Diagnostic) {
+	act.diagnostics = append(act.diagnostics, Diagnostic{
+		Diagnostic: d,
+		Analyzer:   act.a,
+		Related:    act.getRelatedLocations(d),
+	})
+}
+
+// getRelatedLocations finds related locations of diagnostics while types of
+// the package are loaded. analysis.Diagnostic of the golangci/tools fork of
+// x/tools has no Related field: only locations known from messages of
+// analyzers are found.
+func (act *action) getRelatedLocations(d analysis.Diagnostic) []result.RelatedLocation {
+	if act.a != shadow.Analyzer {
+		return nil
+	}
+
+	// "declaration of "x" shadows declaration at line N": find the shadowed
+	// object as the analyzer does, it can be in another file of the package
+	for ident, obj := range act.pass.TypesInfo.Defs {
+		if ident.Pos() != d.Pos || obj == nil || obj.Parent() == nil || obj.Parent().Parent() == nil {
+			continue
+		}
+
+		_, shadowed := obj.Parent().Parent().LookupParent(obj.Name(), obj.Pos())
+		if shadowed == nil || shadowed.Parent() == types.Universe {
+			return nil
+		}
+		return []result.RelatedLocation{{
+			Pos:     act.pkg.Fset.Position(shadowed.Pos()),
+			Message: fmt.Sprintf("shadowed declaration of %q", shadowed.Name()),
+		}}
+	}
+	return nil
+}
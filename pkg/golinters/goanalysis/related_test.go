@@ -0,0 +1,60 @@
+package goanalysis
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/shadow"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestShadowRelatedLocations(t *testing.T) {
+	const src = `package p
+
+var err error
+
+func f() {
+	err := g()
+	_ = err
+}
+
+func g() error { return nil }
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	require.NoError(t, err)
+
+	info := &types.Info{Defs: map[*ast.Ident]types.Object{}}
+	_, err = (&types.Config{Importer: importer.Default()}).Check("p", fset, []*ast.File{f}, info)
+	require.NoError(t, err)
+
+	var shadowing *ast.Ident
+	ast.Inspect(f, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "err" && fset.Position(ident.Pos()).Line == 6 {
+			shadowing = ident
+		}
+		return shadowing == nil
+	})
+	require.NotNil(t, shadowing)
+
+	act := &action{
+		a:    shadow.Analyzer,
+		pkg:  &packages.Package{Fset: fset},
+		pass: &analysis.Pass{TypesInfo: info},
+	}
+	act.report(analysis.Diagnostic{Pos: shadowing.Pos(), Message: `declaration of "err" shadows declaration at line 3`})
+
+	require.Len(t, act.diagnostics, 1)
+	related := act.diagnostics[0].Related
+	require.Len(t, related, 1)
+	assert.Equal(t, 3, related[0].Pos.Line)
+	assert.Equal(t, "p.go", related[0].Pos.Filename)
+	assert.Equal(t, `shadowed declaration of "err"`, related[0].Message)
+}
@@ -37,6 +37,8 @@ import (
 
 	"github.com/golangci/golangci-lint/pkg/golinters/goanalysis/load"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
 
 	"github.com/pkg/errors"
 
@@ -75,6 +77,7 @@ type Diagnostic struct {
 	analysis.Diagnostic
 	Analyzer *analysis.Analyzer
 	Position token.Position
+	Related  []result.RelatedLocation // see getRelatedLocations
 }
 
 type runner struct {
@@ -83,18 +86,42 @@ type runner struct {
 	pkgCache         *pkgcache.Cache
 	loadGuard        *load.Guard
 	needWholeProgram bool
+	profiler         *timeutils.Profiler
+	workerID         int               // of the lint worker running the linter
+	overlay          map[string][]byte // contents replacing files on disk by absolute file paths
 }
 
-func newRunner(prefix string, logger logutils.Log, pkgCache *pkgcache.Cache, loadGuard *load.Guard, needWholeProgram bool) *runner {
+func newRunner(prefix string, logger logutils.Log, pkgCache *pkgcache.Cache, loadGuard *load.Guard,
+	needWholeProgram bool, profiler *timeutils.Profiler, workerID int, overlay map[string][]byte) *runner {
 	return &runner{
 		prefix:           prefix,
 		log:              logger,
 		pkgCache:         pkgCache,
 		loadGuard:        loadGuard,
 		needWholeProgram: needWholeProgram,
+		profiler:         profiler,
+		workerID:         workerID,
+		overlay:          overlay,
 	}
 }
 
+// workerIDsPerLintWorker separates ids of workers of runners: workers of
+// the runner of the lint worker N have ids N001, N002 and so on.
+const workerIDsPerLintWorker = 1000
+
+// workerPool is a pool of ids of workers: a worker id is taken for loading of
+// a package or for running of an analyzer. It limits parallelism and
+// attributes profiled spans to workers.
+type workerPool chan int
+
+func newWorkerPool(lintWorkerID, size int) workerPool {
+	p := make(workerPool, size)
+	for i := 1; i <= size; i++ {
+		p <- lintWorkerID*workerIDsPerLintWorker + i
+	}
+	return p
+}
+
 // Run loads the packages specified by args using go/packages,
 // then applies the specified analyzers to them.
 // Analysis flags must already have been set.
@@ -145,6 +172,7 @@ func (r *runner) prepareAnalysis(pkgs []*packages.Package,
 				objectFacts:       make(map[objectFactKey]analysis.Fact),
 				packageFacts:      make(map[packageFactKey]analysis.Fact),
 				needWholeProgram:  r.needWholeProgram,
+				profiler:          r.profiler,
 			}
 
 			// Add a dependency on each required analyzers.
@@ -225,6 +253,8 @@ func (r *runner) analyze(pkgs []*packages.Package, analyzers []*analysis.Analyze
 			log:        r.log,
 			actions:    actionPerPkg[pkg],
 			loadGuard:  r.loadGuard,
+			profiler:   r.profiler,
+			overlay:    r.overlay,
 			dependents: 1, // self dependent
 		}
 	}
@@ -235,7 +265,8 @@ func (r *runner) analyze(pkgs []*packages.Package, analyzers []*analysis.Analyze
 	// Limit memory and IO usage.
 	gomaxprocs := runtime.GOMAXPROCS(-1)
 	debugf("Analyzing at most %d packages in parallel", gomaxprocs)
-	loadSem := make(chan struct{}, gomaxprocs)
+	loadWorkers := newWorkerPool(r.workerID, gomaxprocs)
+	analysisWorkers := newWorkerPool(r.workerID, gomaxprocs)
 
 	var wg sync.WaitGroup
 	debugf("There are %d initial and %d total packages", len(initialPkgs), len(loadingPackages))
@@ -243,7 +274,7 @@ func (r *runner) analyze(pkgs []*packages.Package, analyzers []*analysis.Analyze
 		if lp.isInitial {
 			wg.Add(1)
 			go func(lp *loadingPackage) {
-				lp.analyzeRecursive(r.needWholeProgram, loadSem)
+				lp.analyzeRecursive(r.needWholeProgram, loadWorkers, analysisWorkers)
 				wg.Done()
 			}(lp)
 		}
@@ -299,7 +330,8 @@ func extractDiagnostics(roots []*action) (retDiags []Diagnostic, retErrors []err
 				}
 				seen[k] = true
 
-				retDiags = append(retDiags, Diagnostic{Diagnostic: diag, Analyzer: act.a, Position: posn})
+				diag.Position = posn
+				retDiags = append(retDiags, diag)
 			}
 		}
 	}
@@ -342,7 +374,7 @@ type action struct {
 	objectFacts         map[objectFactKey]analysis.Fact
 	packageFacts        map[packageFactKey]analysis.Fact
 	result              interface{}
-	diagnostics         []analysis.Diagnostic
+	diagnostics         []Diagnostic
 	err                 error
 	duration            time.Duration
 	log                 logutils.Log
@@ -352,6 +384,8 @@ type action struct {
 	loadCachedFactsDone bool
 	loadCachedFactsOk   bool
 	needWholeProgram    bool
+	profiler            *timeutils.Profiler
+	workerID            int // of the worker running the analyzer
 }
 
 type objectFactKey struct {
@@ -448,6 +482,9 @@ func (act *action) analyze() {
 		return
 	}
 
+	span := act.profiler.StartSpan(timeutils.ProfileCategoryAnalyzers, act.String(), act.workerID)
+	defer span.End()
+
 	// Plumb the output values of the dependencies
 	// into the inputs of this action.  Also facts.
 	inputs := make(map[*analysis.Analyzer]interface{})
@@ -477,7 +514,7 @@ func (act *action) analyze() {
 		TypesInfo:         act.pkg.TypesInfo,
 		TypesSizes:        act.pkg.TypesSizes,
 		ResultOf:          inputs,
-		Report:            func(d analysis.Diagnostic) { act.diagnostics = append(act.diagnostics, d) },
+		Report:            act.report,
 		ImportObjectFact:  act.importObjectFact,
 		ExportObjectFact:  act.exportObjectFact,
 		ImportPackageFact: act.importPackageFact,
@@ -789,6 +826,8 @@ type loadingPackage struct {
 	log         logutils.Log
 	actions     []*action // all actions with this package
 	loadGuard   *load.Guard
+	profiler    *timeutils.Profiler
+	overlay     map[string][]byte
 	dependents  int32 // number of depending on it packages
 	analyzeOnce sync.Once
 	decUseMutex sync.Mutex
@@ -849,10 +888,9 @@ func sizeOfReflectValueTreeBytes(rv reflect.Value, visitedPtrs map[uintptr]struc
 	}
 }
 
-func (lp *loadingPackage) decUse() {
-	lp.decUseMutex.Lock()
-	defer lp.decUseMutex.Unlock()
-
+// releaseAnalysisData frees analysis passes and results of the package:
+// they aren't needed after all the actions of the package were run.
+func (lp *loadingPackage) releaseAnalysisData() {
 	for _, act := range lp.actions {
 		pass := act.pass
 		if pass == nil {
@@ -880,6 +918,13 @@ func (lp *loadingPackage) decUse() {
 			act.result = nil
 		}
 	}
+}
+
+func (lp *loadingPackage) decUse() {
+	lp.decUseMutex.Lock()
+	defer lp.decUseMutex.Unlock()
+
+	lp.releaseAnalysisData()
 
 	lp.pkg.Syntax = nil
 	lp.pkg.TypesInfo = nil
@@ -910,36 +955,50 @@ func (lp *loadingPackage) decUse() {
 	lp.actions = nil
 }
 
-func (lp *loadingPackage) analyzeRecursive(needWholeProgram bool, loadSem chan struct{}) {
+func (lp *loadingPackage) analyzeRecursive(needWholeProgram bool, loadWorkers, analysisWorkers workerPool) {
 	lp.analyzeOnce.Do(func() {
 		// Load the direct dependencies, in parallel.
 		var wg sync.WaitGroup
 		wg.Add(len(lp.imports))
 		for _, imp := range lp.imports {
 			go func(imp *loadingPackage) {
-				imp.analyzeRecursive(needWholeProgram, loadSem)
+				imp.analyzeRecursive(needWholeProgram, loadWorkers, analysisWorkers)
 				wg.Done()
 			}(imp)
 		}
 		wg.Wait()
-		lp.analyze(needWholeProgram, loadSem)
+		lp.analyze(needWholeProgram, loadWorkers, analysisWorkers)
 	})
 }
 
-func (lp *loadingPackage) analyze(needWholeProgram bool, loadSem chan struct{}) {
-	loadSem <- struct{}{}
+func (lp *loadingPackage) analyze(needWholeProgram bool, loadWorkers, analysisWorkers workerPool) {
+	// Throttle loading of new packages while heap usage exceeds the memory limit.
+	// Wait for memory before taking a worker: a waiting package must not block others.
+	lp.loadGuard.AcquireMemory()
+	defer lp.loadGuard.ReleaseMemory()
+
+	workerID := <-loadWorkers
 	defer func() {
-		<-loadSem
+		loadWorkers <- workerID
 	}()
 
 	defer func() {
 		if !needWholeProgram {
 			// Save memory on unused more fields.
 			lp.decUse()
+		} else if lp.loadGuard.IsUnderMemoryPressure() {
+			// Syntax and types are still needed by other linters, but analysis data isn't.
+			lp.decUseMutex.Lock()
+			lp.releaseAnalysisData()
+			lp.decUseMutex.Unlock()
 		}
 	}()
 
-	if err := lp.loadWithFacts(needWholeProgram); err != nil {
+	var err error
+	lp.profiler.Track(timeutils.ProfileCategoryLoading, lp.String(), workerID, func() {
+		err = lp.loadWithFacts(needWholeProgram)
+	})
+	if err != nil {
 		werr := errors.Wrapf(err, "failed to load package %s", lp.pkg.Name)
 		// Don't need to write error to errCh, it will be extracted and reported on another layer.
 		// Unblock depending actions and propagate error.
@@ -958,7 +1017,10 @@ func (lp *loadingPackage) analyze(needWholeProgram bool, loadSem chan struct{})
 
 			act.waitUntilDependingAnalyzersWorked()
 
+			// Take a worker only after waiting: a waiting action must not block others.
+			act.workerID = <-analysisWorkers
 			act.analyzeSafe()
+			analysisWorkers <- act.workerID
 		}(act)
 	}
 	actsWg.Wait()
@@ -986,7 +1048,11 @@ func (lp *loadingPackage) loadFromSource() error {
 	// bookkeeping and potentially false sharing of cache lines.
 	pkg.Syntax = make([]*ast.File, len(pkg.CompiledGoFiles))
 	for i, file := range pkg.CompiledGoFiles {
-		f, err := parser.ParseFile(pkg.Fset, file, nil, parser.ParseComments)
+		var src interface{}
+		if content, ok := lp.overlay[file]; ok {
+			src = content
+		}
+		f, err := parser.ParseFile(pkg.Fset, file, src, parser.ParseComments)
 		if err != nil {
 			pkg.Errors = append(pkg.Errors, lp.convertError(err)...)
 			return err
@@ -1176,12 +1242,24 @@ func (lp *loadingPackage) loadWithFacts(needWholeProgram bool) error {
 		return errors.Wrap(err, "could not load export data")
 	}
 
+	underMemoryPressure := lp.loadGuard.IsUnderMemoryPressure()
 	needLoadFromSource := false
 	for _, act := range lp.actions {
 		if act.loadCachedFacts() {
 			continue
 		}
 
+		if underMemoryPressure {
+			// Analysis of a dependency from source takes much more memory than loading of it
+			// from export data: keep the export data and don't compute its facts.
+			factsCacheDebugf("Memory limit is exceeded, don't analyze %s from source", act)
+			lp.loadGuard.ReportMemoryPressureOnce(func() {
+				lp.log.Warnf("Memory limit is exceeded: dependencies are loaded from export data without " +
+					"analyzing them, facts of some dependencies can be incomplete and so can be the results")
+			})
+			continue
+		}
+
 		// Cached facts loading failed: analyze later the action from source.
 		factsCacheDebugf("Loading of facts for %s failed, analyze it from source later", act)
 		act.needAnalyzeSource = true // can't be set in parallel
@@ -3,6 +3,9 @@ package golinters
 import (
 	"context"
 	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
 
 	goconstAPI "github.com/golangci/goconst"
 
@@ -21,7 +24,7 @@ func (Goconst) Desc() string {
 }
 
 func (lint Goconst) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
-	var goconstIssues []goconstAPI.Issue
+	var res []result.Issue
 	cfg := goconstAPI.Config{
 		MatchWithConstants: true,
 		MinStringLength:    lintCtx.Settings().Goconst.MinStringLen,
@@ -38,12 +41,19 @@ func (lint Goconst) Run(ctx context.Context, lintCtx *linter.Context) ([]result.
 			return nil, err
 		}
 
-		goconstIssues = append(goconstIssues, issues...)
-	}
-	if len(goconstIssues) == 0 {
-		return nil, nil
+		// goconst counts occurrences in a package: don't relate strings of other packages
+		var occurrences map[string][]token.Position
+		if len(issues) != 0 {
+			occurrences = getGoconstOccurrences(files, fset)
+		}
+		res = append(res, lint.makeIssues(issues, occurrences, lintCtx)...)
 	}
 
+	return res, nil
+}
+
+func (lint Goconst) makeIssues(goconstIssues []goconstAPI.Issue, occurrences map[string][]token.Position,
+	lintCtx *linter.Context) []result.Issue {
 	res := make([]result.Issue, 0, len(goconstIssues))
 	for _, i := range goconstIssues {
 		textBegin := fmt.Sprintf("string %s has %d occurrences", formatCode(i.Str, lintCtx.Cfg), i.OccurencesCount)
@@ -53,12 +63,60 @@ func (lint Goconst) Run(ctx context.Context, lintCtx *linter.Context) ([]result.
 		} else {
 			textEnd = fmt.Sprintf(", but such constant %s already exists", formatCode(i.MatchingConst, lintCtx.Cfg))
 		}
+		var related []result.RelatedLocation
+		for _, pos := range occurrences[i.Str] {
+			if pos != i.Pos {
+				related = append(related, result.RelatedLocation{Pos: pos, Message: "another occurrence of the string"})
+			}
+		}
 		res = append(res, result.Issue{
 			Pos:        i.Pos,
 			Text:       textBegin + textEnd,
+			Related:    related,
 			FromLinter: lint.Name(),
 		})
 	}
 
-	return res, nil
+	return res
+}
+
+// getGoconstOccurrences finds positions of strings in the same places as goconst does:
+// goconst reports only the first occurrence of a string.
+func getGoconstOccurrences(files []*ast.File, fset *token.FileSet) map[string][]token.Position {
+	ret := map[string][]token.Position{}
+	add := func(e ast.Expr) {
+		lit, ok := e.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return
+		}
+		str := strings.Replace(lit.Value, `"`, "", 2)
+		ret[str] = append(ret[str], fset.Position(lit.Pos()))
+	}
+
+	for _, f := range files {
+		ast.Inspect(f, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.AssignStmt:
+				for _, e := range n.Rhs {
+					add(e)
+				}
+			case *ast.BinaryExpr:
+				if n.Op == token.EQL || n.Op == token.NEQ {
+					add(n.X)
+					add(n.Y)
+				}
+			case *ast.CaseClause:
+				for _, e := range n.List {
+					add(e)
+				}
+			case *ast.ReturnStmt:
+				for _, e := range n.Results {
+					add(e)
+				}
+			}
+			return true
+		})
+	}
+
+	return ret
 }
@@ -74,6 +74,14 @@ func (lint Gocritic) configureCheckerInfo(info *lintpack.CheckerInfo, allParams
 	return nil
 }
 
+func (Gocritic) RuleIDs() []string {
+	var ret []string
+	for _, info := range lintpack.GetCheckersInfo() {
+		ret = append(ret, info.Name)
+	}
+	return ret
+}
+
 func (lint Gocritic) buildEnabledCheckers(lintCtx *linter.Context, lintpackCtx *lintpack.Context) ([]*lintpack.Checker, error) {
 	s := lintCtx.Settings().Gocritic
 	allParams := s.GetLowercasedParams()
@@ -157,6 +165,7 @@ func (lint Gocritic) runOnFile(ctx *lintpack.Context, f *ast.File, checkers []*l
 				ret <- result.Issue{
 					Pos:        pos,
 					Text:       fmt.Sprintf("%s: %s", c.Info.Name, warn.Text),
+					RuleID:     c.Info.Name,
 					FromLinter: lint.Name(),
 				}
 			}
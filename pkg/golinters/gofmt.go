@@ -294,10 +294,12 @@ func (g Gofmt) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue
 		var diff []byte
 		var err error
 		if g.UseGoimports {
-			imports.LocalPrefix = lintCtx.Settings().Goimports.LocalPrefixes
-			diff, err = goimportsAPI.Run(f)
+			if prefix := lintCtx.Settings().Goimports.LocalPrefixes; imports.LocalPrefix != prefix {
+				imports.LocalPrefix = prefix // don't write it in concurrent runs with the same value
+			}
+			diff, err = g.runGoimports(lintCtx, f)
 		} else {
-			diff, err = gofmtAPI.Run(f, lintCtx.Settings().Gofmt.Simplify)
+			diff, err = g.runGofmt(lintCtx, f)
 		}
 		if err != nil { // TODO: skip
 			return nil, err
@@ -316,3 +318,51 @@ func (g Gofmt) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue
 
 	return issues, nil
 }
+
+// goimportsOptions are options of the goimports library.
+var goimportsOptions = &imports.Options{
+	TabWidth:  8,
+	TabIndent: true,
+	Comments:  true,
+	Fragment:  true,
+}
+
+func (g Gofmt) runGoimports(lintCtx *linter.Context, f string) ([]byte, error) {
+	src, ok := lintCtx.FileCache.GetOverlay(f)
+	if !ok {
+		return goimportsAPI.Run(f)
+	}
+
+	// goimports looks for sibling files of the file: process its overlaid
+	// content in place instead of a temporary copy
+	res, err := imports.Process(f, src, goimportsOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(src, res) {
+		return nil, nil
+	}
+
+	return diffContents(src, res, f)
+}
+
+func (g Gofmt) runGofmt(lintCtx *linter.Context, f string) ([]byte, error) {
+	paths, copies, err := copyOverlaidFiles(lintCtx.FileCache, []string{f})
+	if err != nil {
+		return nil, err
+	}
+	defer copies.remove()
+
+	path := paths[0]
+	diff, err := gofmtAPI.Run(path, lintCtx.Settings().Gofmt.Simplify)
+	if err != nil {
+		return nil, errors.New(strings.Replace(err.Error(), path, f, -1))
+	}
+
+	if diff == nil || path == f {
+		return diff, nil
+	}
+
+	return replaceDiffFilename(diff, f)
+}
@@ -22,6 +22,16 @@ func (Golint) Desc() string {
 	return "Golint differs from gofmt. Gofmt reformats Go source code, whereas golint prints out style mistakes"
 }
 
+// golintCategories are categories of golint problems, they are rule IDs of issues.
+var golintCategories = []string{
+	"arg-order", "comments", "context", "errors", "imports", "indent", "mixed-caps", "naming",
+	"range-loop", "time", "type-inference", "typechecking", "unary-op", "unexported-type-in-api", "zero-value",
+}
+
+func (Golint) RuleIDs() []string {
+	return golintCategories
+}
+
 func (g Golint) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
 	var issues []result.Issue
 	var lintErr error
@@ -62,9 +72,10 @@ func (g Golint) lintPkg(minConfidence float64, files []*ast.File, fset *token.Fi
 			issues = append(issues, result.Issue{
 				Pos:        ps[idx].Position,
 				Text:       ps[idx].Text,
+				RuleID:     ps[idx].Category,
 				FromLinter: g.Name(),
 			})
-			// TODO: use p.Link and p.Category
+			// TODO: use p.Link
 		}
 	}
 
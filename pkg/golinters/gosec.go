@@ -6,6 +6,7 @@ import (
 	"go/token"
 	"io/ioutil"
 	"log"
+	"sort"
 	"strconv"
 
 	"github.com/securego/gosec"
@@ -25,6 +26,15 @@ func (Gosec) Desc() string {
 	return "Inspects source code for security problems"
 }
 
+func (Gosec) RuleIDs() []string {
+	var ret []string
+	for id := range rules.Generate() {
+		ret = append(ret, id)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
 func (lint Gosec) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
 	gasConfig := gosec.NewConfig()
 	enabledRules := rules.Generate()
@@ -60,6 +70,7 @@ func (lint Gosec) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Is
 				Line:     line,
 			},
 			Text:       text,
+			RuleID:     i.RuleID,
 			LineRange:  r,
 			FromLinter: lint.Name(),
 		})
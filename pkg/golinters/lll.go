@@ -2,13 +2,14 @@ package golinters
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"go/token"
-	"os"
 	"strings"
 	"unicode/utf8"
 
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
@@ -23,17 +24,17 @@ func (Lll) Desc() string {
 	return "Reports long lines"
 }
 
-func (lint Lll) getIssuesForFile(filename string, maxLineLen int, tabSpaces string) ([]result.Issue, error) {
+func (lint Lll) getIssuesForFile(fileCache *fsutils.FileCache, filename string,
+	maxLineLen int, tabSpaces string) ([]result.Issue, error) {
 	var res []result.Issue
 
-	f, err := os.Open(filename)
+	content, err := fileCache.GetFileBytes(filename)
 	if err != nil {
-		return nil, fmt.Errorf("can't open file %s: %s", filename, err)
+		return nil, fmt.Errorf("can't read file %s: %s", filename, err)
 	}
-	defer f.Close()
 
 	lineNumber := 1
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
 	for scanner.Scan() {
 		line := scanner.Text()
 		line = strings.Replace(line, "\t", tabSpaces, -1)
@@ -84,7 +85,7 @@ func (lint Lll) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issu
 	var res []result.Issue
 	spaces := strings.Repeat(" ", lintCtx.Settings().Lll.TabWidth)
 	for _, f := range getAllFileNames(lintCtx) {
-		issues, err := lint.getIssuesForFile(f, lintCtx.Settings().Lll.LineLength, spaces)
+		issues, err := lint.getIssuesForFile(lintCtx.FileCache, f, lintCtx.Settings().Lll.LineLength, spaces)
 		if err != nil {
 			return nil, err
 		}
@@ -3,6 +3,7 @@ package golinters
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/golangci/golangci-lint/pkg/logutils"
 
@@ -246,6 +247,7 @@ func (m megacheck) runMegacheck(ctx context.Context, lintCtx *linter.Context) ([
 			issues = append(issues, result.Issue{
 				FromLinter: MegacheckUnusedName,
 				Text:       p.Message,
+				RuleID:     p.Check,
 				Pos:        p.Pos,
 			})
 		}
@@ -275,6 +277,36 @@ func (m megacheck) Analyzers() []*analysis.Analyzer {
 	return allAnalyzers
 }
 
+func (m megacheck) ChildLinterNames() []string {
+	var ret []string
+	if m.staticcheckEnabled {
+		ret = append(ret, MegacheckStaticcheckName)
+	}
+	if m.gosimpleEnabled {
+		ret = append(ret, MegacheckGosimpleName)
+	}
+	if m.unusedEnabled {
+		ret = append(ret, MegacheckUnusedName)
+	}
+	if m.stylecheckEnabled {
+		ret = append(ret, MegacheckStylecheckName)
+	}
+	return ret
+}
+
+func (m megacheck) RuleIDs() []string {
+	var ret []string
+	for a := range m.AnalyzerToLinterNameMapping() {
+		ret = append(ret, a.Name)
+	}
+	if m.unusedEnabled {
+		// unused reports U1001 instead of U1000 in whole program mode
+		ret = append(ret, "U1000", "U1001")
+	}
+	sort.Strings(ret)
+	return ret
+}
+
 func (megacheck) Cfg() map[string]map[string]interface{} {
 	return nil
 }
@@ -0,0 +1,119 @@
+package golinters
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+)
+
+// overlaidCopies are temporary copies of overlaid files, e.g. given by
+// --stdin-filename, for linter libraries reading files only by name.
+type overlaidCopies struct {
+	dir       string
+	origNames map[string]string
+}
+
+// copyOverlaidFiles returns the files to pass to such libraries: overlaid
+// files are replaced by their copies. Call remove when done.
+func copyOverlaidFiles(fc *fsutils.FileCache, files []string) ([]string, *overlaidCopies, error) {
+	c := &overlaidCopies{
+		origNames: map[string]string{},
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		content, ok := fc.GetOverlay(f)
+		if !ok {
+			paths = append(paths, f)
+			continue
+		}
+
+		if c.dir == "" {
+			dir, err := ioutil.TempDir("", "golangci-lint-overlay")
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed to make temp dir for overlaid files")
+			}
+			c.dir = dir
+		}
+
+		// keep the base name: a subdir per file makes names unique
+		fileDir := filepath.Join(c.dir, strconv.Itoa(len(c.origNames)))
+		if err := os.Mkdir(fileDir, os.ModePerm); err != nil {
+			c.remove()
+			return nil, nil, errors.Wrapf(err, "failed to make temp dir for overlaid file %s", f)
+		}
+
+		path := filepath.Join(fileDir, filepath.Base(f))
+		if err := ioutil.WriteFile(path, content, 0600); err != nil {
+			c.remove()
+			return nil, nil, errors.Wrapf(err, "failed to write overlaid file %s", f)
+		}
+
+		c.origNames[path] = f
+		paths = append(paths, path)
+	}
+
+	return paths, c, nil
+}
+
+// origName maps a path returned by copyOverlaidFiles back to the linted file.
+func (c *overlaidCopies) origName(path string) string {
+	if f, ok := c.origNames[path]; ok {
+		return f
+	}
+
+	return path
+}
+
+func (c *overlaidCopies) remove() {
+	if c.dir != "" {
+		os.RemoveAll(c.dir)
+	}
+}
+
+// diffContents returns the unified diff between contents of the file in the
+// format of the gofmt library.
+func diffContents(b1, b2 []byte, filename string) ([]byte, error) {
+	dir, err := ioutil.TempDir("", "golangci-lint-diff")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make temp dir for diff")
+	}
+	defer os.RemoveAll(dir)
+
+	f1, f2 := filepath.Join(dir, "orig"), filepath.Join(dir, "new")
+	if err := ioutil.WriteFile(f1, b1, 0600); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(f2, b2, 0600); err != nil {
+		return nil, err
+	}
+
+	data, err := exec.Command("diff", "-u", f1, f2).CombinedOutput()
+	if len(data) == 0 {
+		return nil, errors.Wrap(err, "failed to run diff")
+	}
+
+	// diff exits with a non-zero status when the files don't match
+	return replaceDiffFilename(data, filename)
+}
+
+// replaceDiffFilename makes the header of the unified diff refer to the file.
+func replaceDiffFilename(diff []byte, filename string) ([]byte, error) {
+	bs := bytes.SplitN(diff, []byte{'\n'}, 3)
+	if len(bs) < 3 {
+		return nil, fmt.Errorf("got unexpected diff for %s", filename)
+	}
+
+	f := filepath.ToSlash(filename)
+	bs[0] = []byte(fmt.Sprintf("--- %s.orig", f))
+	bs[1] = []byte(fmt.Sprintf("+++ %s", f))
+	return bytes.Join(bs, []byte{'\n'}), nil
+}
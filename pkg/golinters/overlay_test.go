@@ -0,0 +1,121 @@
+package golinters
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const formattedSource = `package p
+
+func f() int {
+	return 1
+}
+`
+
+const unformattedSource = `package p
+
+func f() int {
+	return 1
+}
+
+var g   = ` + "`" + `a line being longer than the limit of the line length` + "`" + `
+`
+
+func newOverlaidLintContext(t *testing.T, dir string) (*linter.Context, string) {
+	filename := filepath.Join(dir, "p.go")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(formattedSource), os.ModePerm))
+
+	fileCache := fsutils.NewFileCache()
+	require.NoError(t, fileCache.SetOverlay(filename, []byte(unformattedSource)))
+
+	cfg := &config.Config{}
+	cfg.LintersSettings.Lll.LineLength = 40
+	cfg.LintersSettings.Lll.TabWidth = 1
+	cfg.LintersSettings.Dupl.Threshold = 150
+
+	log := logutils.NewStderrLog("test")
+	return &linter.Context{
+		Packages:  []*packages.Package{{GoFiles: []string{filename}}},
+		Cfg:       cfg,
+		FileCache: fileCache,
+		LineCache: fsutils.NewLineCache(fileCache),
+		Log:       log,
+	}, filename
+}
+
+func assertIssuesInOverlaidFile(t *testing.T, issues []result.Issue, filename string) {
+	require.NotEmpty(t, issues)
+	for _, i := range issues {
+		assert.Equal(t, filename, i.FilePath())
+		assert.Equal(t, 7, i.Line())
+	}
+}
+
+func TestGofmtLintsOverlaidContent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lintCtx, filename := newOverlaidLintContext(t, dir)
+
+	issues, err := Gofmt{}.Run(context.Background(), lintCtx)
+	require.NoError(t, err)
+	assertIssuesInOverlaidFile(t, issues, filename)
+
+	issues, err = Gofmt{UseGoimports: true}.Run(context.Background(), lintCtx)
+	require.NoError(t, err)
+	assertIssuesInOverlaidFile(t, issues, filename)
+}
+
+func TestLllLintsOverlaidContent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lintCtx, filename := newOverlaidLintContext(t, dir)
+
+	issues, err := Lll{}.Run(context.Background(), lintCtx)
+	require.NoError(t, err)
+	assertIssuesInOverlaidFile(t, issues, filename)
+}
+
+func TestCopyOverlaidFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lintCtx, filename := newOverlaidLintContext(t, dir)
+	other := filepath.Join(dir, "other.go")
+
+	paths, copies, err := copyOverlaidFiles(lintCtx.FileCache, []string{filename, other})
+	require.NoError(t, err)
+
+	require.Len(t, paths, 2)
+	assert.NotEqual(t, filename, paths[0])
+	assert.Equal(t, filepath.Base(filename), filepath.Base(paths[0]))
+	assert.Equal(t, other, paths[1])
+	assert.Equal(t, filename, copies.origName(paths[0]))
+	assert.Equal(t, other, copies.origName(paths[1]))
+
+	content, err := ioutil.ReadFile(paths[0])
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(content), "length`\n"))
+
+	copies.remove()
+	_, err = os.Stat(paths[0])
+	assert.True(t, os.IsNotExist(err))
+}
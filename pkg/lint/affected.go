@@ -0,0 +1,281 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golangci/revgrep"
+	"github.com/pkg/errors"
+	diffpkg "github.com/sourcegraph/go-diff/diff"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/gitutil"
+)
+
+// ErrNoAffectedPackages is returned when no package is affected by the changes
+// in the --new-affected-only mode: there is nothing to analyze.
+var ErrNoAffectedPackages = errors.New("no packages are affected by changes")
+
+// filesAffectingAllPackages are files a change of which can affect any package.
+var filesAffectingAllPackages = []string{"go.mod", "go.sum", "vendor/modules.txt"}
+
+func (cl *ContextLoader) readPatch() (io.Reader, []string, error) {
+	icfg := cl.cfg.Issues
+	if icfg.DiffPatchFilePath != "" {
+		patch, err := ioutil.ReadFile(icfg.DiffPatchFilePath)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "can't read from patch file %s", icfg.DiffPatchFilePath)
+		}
+		return bytes.NewReader(patch), nil, nil
+	}
+
+	// The same patch as in the diff processor.
+	if patch := os.Getenv("GOLANGCI_DIFF_PROCESSOR_PATCH"); patch != "" {
+		return strings.NewReader(patch), nil, nil
+	}
+
+	if icfg.DiffStaged {
+		patch, err := gitutil.StagedPatch()
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "can't get staged changes")
+		}
+		return bytes.NewReader(patch), nil, nil
+	}
+
+	return revgrep.GitPatch(icfg.DiffFromRevision, "")
+}
+
+// getChangedFiles returns absolute paths of added, changed and deleted files.
+func getChangedFiles(patch io.Reader, newFiles []string) ([]string, error) {
+	var changedFiles []string
+	changedFiles = append(changedFiles, newFiles...)
+
+	if patch != nil {
+		patchData, err := ioutil.ReadAll(patch)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read patch")
+		}
+
+		fileDiffs, err := diffpkg.ParseMultiFileDiff(patchData)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse patch")
+		}
+
+		for _, fd := range fileDiffs {
+			for _, name := range []string{fd.OrigName, fd.NewName} {
+				if name == "" || name == "/dev/null" {
+					continue
+				}
+				if strings.HasPrefix(name, "a/") || strings.HasPrefix(name, "b/") {
+					name = name[2:]
+				}
+				changedFiles = append(changedFiles, name)
+			}
+		}
+	}
+
+	var ret []string
+	seen := map[string]bool{}
+	for _, f := range changedFiles {
+		absPath, err := filepath.Abs(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't make abs path for %s", f)
+		}
+		if !seen[absPath] {
+			seen[absPath] = true
+			ret = append(ret, absPath)
+		}
+	}
+
+	return ret, nil
+}
+
+func pkgDir(pkg *packages.Package) string {
+	for _, files := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles} {
+		if len(files) != 0 {
+			return filepath.Dir(files[0])
+		}
+	}
+
+	return ""
+}
+
+// dirsToArgs converts absolute directories of packages to sorted unique
+// args: directories inside the working directory become relative.
+func dirsToArgs(dirs []string) ([]string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get working dir")
+	}
+
+	argsSet := map[string]bool{}
+	for _, dir := range dirs {
+		if rel, err := filepath.Rel(wd, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			dir = "."
+			if rel != "." {
+				dir += string(filepath.Separator) + rel
+			}
+		}
+		argsSet[dir] = true
+	}
+
+	var args []string
+	for arg := range argsSet {
+		args = append(args, arg)
+	}
+	sort.Strings(args)
+	return args, nil
+}
+
+// findAffectedPackages returns packages containing changed files and all packages
+// transitively importing them. Deleted files affect packages in their directories.
+func findAffectedPackages(pkgs []*packages.Package, changedFiles []string) []*packages.Package {
+	changedFilesSet := map[string]bool{}
+	changedDirs := map[string]bool{}
+	for _, f := range changedFiles {
+		changedFilesSet[f] = true
+		if strings.HasSuffix(f, ".go") {
+			changedDirs[filepath.Dir(f)] = true
+		}
+	}
+
+	isChanged := func(pkg *packages.Package) bool {
+		for _, files := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles} {
+			for _, f := range files {
+				if changedFilesSet[f] {
+					return true
+				}
+			}
+		}
+
+		dir := pkgDir(pkg)
+		return dir != "" && changedDirs[dir]
+	}
+
+	importers := map[string][]*packages.Package{}
+	for _, pkg := range pkgs {
+		for _, imp := range pkg.Imports {
+			importers[imp.ID] = append(importers[imp.ID], pkg)
+		}
+	}
+
+	affected := map[*packages.Package]bool{}
+	var queue []*packages.Package
+	for _, pkg := range pkgs {
+		if isChanged(pkg) {
+			affected[pkg] = true
+			queue = append(queue, pkg)
+		}
+	}
+
+	for len(queue) != 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		for _, importer := range importers[pkg.ID] {
+			if !affected[importer] {
+				affected[importer] = true
+				queue = append(queue, importer)
+			}
+		}
+	}
+
+	var ret []*packages.Package
+	for _, pkg := range pkgs {
+		if affected[pkg] {
+			ret = append(ret, pkg)
+		}
+	}
+
+	return ret
+}
+
+// PackagesGraph is a graph of packages to analyze: only names, files and imports
+// of packages are loaded. It's much faster than loading for linters.
+type PackagesGraph struct {
+	pkgs []*packages.Package
+}
+
+// LoadPackagesGraph loads the graph of packages matching run args.
+func (cl *ContextLoader) LoadPackagesGraph(ctx context.Context) (*PackagesGraph, error) {
+	return cl.loadPackagesGraph(ctx, cl.buildArgs())
+}
+
+func (cl *ContextLoader) loadPackagesGraph(ctx context.Context, args []string) (*PackagesGraph, error) {
+	conf, err := cl.makeLoadConfig(ctx, packages.NeedName|packages.NeedFiles|packages.NeedImports)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := packages.Load(conf, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load packages graph")
+	}
+
+	return &PackagesGraph{pkgs: pkgs}, nil
+}
+
+// AffectedArgs returns args restricted to the directories of the packages affected
+// by changes of the files: only these packages will be loaded from source,
+// all other packages are loaded as dependencies if linters need them.
+// It returns ErrNoAffectedPackages if no package is affected.
+func (cl *ContextLoader) AffectedArgs(g *PackagesGraph, changedFiles []string) ([]string, error) {
+	for _, f := range changedFiles {
+		for _, globalFile := range filesAffectingAllPackages {
+			if strings.HasSuffix(filepath.ToSlash(f), "/"+globalFile) {
+				cl.log.Infof("File %s was changed: analyzing all packages", globalFile)
+				return cl.buildArgs(), nil
+			}
+		}
+	}
+
+	affectedPkgs := findAffectedPackages(g.pkgs, changedFiles)
+	if len(affectedPkgs) == 0 {
+		return nil, ErrNoAffectedPackages
+	}
+
+	var dirs []string
+	for _, pkg := range affectedPkgs {
+		if dir := pkgDir(pkg); dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	retArgs, err := dirsToArgs(dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	cl.log.Infof("Analyzing %d/%d packages affected by %d changed files", len(affectedPkgs), len(g.pkgs), len(changedFiles))
+	return retArgs, nil
+}
+
+// buildAffectedArgs restricts args to the packages affected by the changes
+// from --new, --new-from-rev or --new-from-patch.
+func (cl *ContextLoader) buildAffectedArgs(ctx context.Context) ([]string, error) {
+	patch, newFiles, err := cl.readPatch()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get changes")
+	}
+	if patch == nil && newFiles == nil {
+		cl.log.Warnf("Can't find changes (not a git repository?): analyzing all packages")
+		return cl.buildArgs(), nil
+	}
+
+	changedFiles, err := getChangedFiles(patch, newFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := cl.LoadPackagesGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.AffectedArgs(g, changedFiles)
+}
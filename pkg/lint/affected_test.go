@@ -0,0 +1,78 @@
+package lint
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+const testPatch = `diff --git a/pkg/a/a.go b/pkg/a/a.go
+index 1111111..2222222 100644
+--- a/pkg/a/a.go
++++ b/pkg/a/a.go
+@@ -1,3 +1,4 @@
+ package a
+
++var X = 1
+ func A() {}
+diff --git a/pkg/d/old.go b/pkg/d/old.go
+deleted file mode 100644
+index 3333333..0000000
+--- a/pkg/d/old.go
++++ /dev/null
+@@ -1 +0,0 @@
+-package d
+`
+
+func TestGetChangedFiles(t *testing.T) {
+	files, err := getChangedFiles(strings.NewReader(testPatch), []string{"pkg/new/new.go"})
+	require.NoError(t, err)
+
+	abs := func(p string) string {
+		ret, err := filepath.Abs(p)
+		require.NoError(t, err)
+		return ret
+	}
+	assert.ElementsMatch(t, []string{
+		abs("pkg/new/new.go"),
+		abs("pkg/a/a.go"),
+		abs("pkg/d/old.go"),
+	}, files)
+}
+
+func TestFindAffectedPackages(t *testing.T) {
+	root := filepath.FromSlash("/src/mod")
+	mkPkg := func(id, dir string, imports ...*packages.Package) *packages.Package {
+		pkg := &packages.Package{
+			ID:      id,
+			GoFiles: []string{filepath.Join(root, dir, "file.go")},
+			Imports: map[string]*packages.Package{},
+		}
+		for _, imp := range imports {
+			pkg.Imports[imp.ID] = &packages.Package{ID: imp.ID} // NeedDeps isn't set
+		}
+		return pkg
+	}
+
+	a := mkPkg("mod/a", "a")
+	b := mkPkg("mod/b", "b", a)
+	c := mkPkg("mod/c", "c", b)
+	d := mkPkg("mod/d", "d")
+	e := mkPkg("mod/e", "e", d)
+	unrelated := mkPkg("mod/unrelated", "unrelated")
+	pkgs := []*packages.Package{a, b, c, d, e, unrelated}
+
+	affected := findAffectedPackages(pkgs, []string{filepath.Join(root, "a", "file.go")})
+	assert.Equal(t, []*packages.Package{a, b, c}, affected)
+
+	// Deleted file affects the package in its directory.
+	affected = findAffectedPackages(pkgs, []string{filepath.Join(root, "d", "deleted.go")})
+	assert.Equal(t, []*packages.Package{d, e}, affected)
+
+	affected = findAffectedPackages(pkgs, []string{filepath.Join(root, "README.md")})
+	assert.Empty(t, affected)
+}
@@ -5,6 +5,7 @@ import (
 	"go/parser"
 	"go/token"
 	"path/filepath"
+	"sync"
 
 	"golang.org/x/tools/go/packages"
 
@@ -13,16 +14,56 @@ import (
 )
 
 type File struct {
-	F    *ast.File
-	Fset *token.FileSet
-	Name string
-	Err  error
+	F       *ast.File
+	Fset    *token.FileSet
+	Name    string
+	PkgPath string // empty if the file isn't loaded from a package
+	Err     error
 }
 
 type Cache struct {
-	m   map[string]*File // map from absolute file path to file data
-	s   []*File
-	log logutils.Log
+	m       map[string]*File // map from absolute file path to file data
+	s       []*File
+	overlay map[string][]byte // contents replacing files on disk by absolute file paths
+	parsed  *ParsedFiles      // can be nil
+	log     logutils.Log
+}
+
+// ParsedFiles keeps files parsed by caches between loads of packages
+// in long-running servers: only changed files are parsed again.
+type ParsedFiles struct {
+	mu sync.Mutex
+	m  map[string]*File // by absolute file path
+}
+
+func NewParsedFiles() *ParsedFiles {
+	return &ParsedFiles{m: map[string]*File{}}
+}
+
+// Forget drops the files given by absolute paths: they were changed.
+func (pf *ParsedFiles) Forget(absPaths []string) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	for _, p := range absPaths {
+		delete(pf.m, p)
+		if realPath, err := fsutils.EvalSymlinks(p); err == nil {
+			delete(pf.m, realPath)
+		}
+	}
+}
+
+func (pf *ParsedFiles) get(filePath string) *File {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	return pf.m[filePath]
+}
+
+func (pf *ParsedFiles) set(f *File) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	fCopy := *f // PkgPath is set by callers
+	pf.m[f.Name] = &fCopy
 }
 
 func NewCache(log logutils.Log) *Cache {
@@ -95,8 +136,14 @@ func LoadFromFilenames(log logutils.Log, filenames ...string) *Cache {
 	return c
 }
 
-func LoadFromPackages(pkgs []*packages.Package, log logutils.Log) (*Cache, error) {
+// LoadFromPackages builds the cache from packages loaded with the overlay:
+// files not parsed by the packages loader are parsed from the overlay too.
+// Files are taken from parsed if it's not nil instead of parsing them again.
+func LoadFromPackages(pkgs []*packages.Package, overlay map[string][]byte, parsed *ParsedFiles,
+	log logutils.Log) (*Cache, error) {
 	c := NewCache(log)
+	c.overlay = overlay
+	c.parsed = parsed
 
 	for _, pkg := range pkgs {
 		c.loadFromPackage(pkg)
@@ -124,9 +171,10 @@ func (c *Cache) loadFromPackage(pkg *packages.Package) {
 		for _, filename := range c.extractFilenamesForAstFile(pkg.Fset, f) {
 			filePath := c.normalizeFilename(filename)
 			c.m[filePath] = &File{
-				F:    f,
-				Fset: pkg.Fset,
-				Name: filePath,
+				F:       f,
+				Fset:    pkg.Fset,
+				Name:    filePath,
+				PkgPath: pkg.PkgPath,
 			}
 		}
 	}
@@ -137,6 +185,7 @@ func (c *Cache) loadFromPackage(pkg *packages.Package) {
 		filePath = c.normalizeFilename(filePath)
 		if c.m[filePath] == nil {
 			c.parseFile(filePath, fset)
+			c.m[filePath].PkgPath = pkg.PkgPath
 		}
 	}
 }
@@ -148,8 +197,19 @@ func (c *Cache) parseFile(filePath string, fset *token.FileSet) {
 
 	filePath = c.normalizeFilename(filePath)
 
+	var src interface{}
+	if content, ok := c.overlay[filePath]; ok {
+		src = content
+	} else if c.parsed != nil {
+		if f := c.parsed.get(filePath); f != nil {
+			fCopy := *f
+			c.m[filePath] = &fCopy
+			return
+		}
+	}
+
 	// comments needed by e.g. golint
-	f, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	f, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
 	c.m[filePath] = &File{
 		F:    f,
 		Fset: fset,
@@ -158,5 +218,7 @@ func (c *Cache) parseFile(filePath string, fset *token.FileSet) {
 	}
 	if err != nil {
 		c.log.Warnf("Can't parse AST of %s: %s", filePath, err)
+	} else if c.parsed != nil && src == nil {
+		c.parsed.set(c.m[filePath])
 	}
 }
@@ -0,0 +1,153 @@
+package lint
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+type explainedIssue struct {
+	issue     result.Issue
+	processor string // a processor which filtered out the issue, empty if the issue is shown
+	reason    string
+}
+
+// issuesExplainer follows issues at a location of code through processors
+// to tell which processor hides them and why.
+type issuesExplainer struct {
+	location string
+	path     string // absolute
+	line     int
+
+	issues []explainedIssue
+}
+
+func newIssuesExplainer(location string) (*issuesExplainer, error) {
+	colon := strings.LastIndex(location, ":")
+	if colon == -1 {
+		return nil, fmt.Errorf("invalid location %q to explain: file.go:line is expected", location)
+	}
+
+	line, err := strconv.Atoi(location[colon+1:])
+	if err != nil || line <= 0 {
+		return nil, fmt.Errorf("invalid line in location %q to explain", location)
+	}
+
+	path, err := filepath.Abs(location[:colon])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to abs-ify path %q", location[:colon])
+	}
+
+	return &issuesExplainer{
+		location: location,
+		path:     path,
+		line:     line,
+	}, nil
+}
+
+func (e *issuesExplainer) matches(i *result.Issue) bool {
+	if i.Line() != e.line {
+		return false
+	}
+
+	path := i.FilePath()
+	if !filepath.IsAbs(path) {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return false
+		}
+		path = absPath
+	}
+	return path == e.path
+}
+
+// issuesAt returns not suppressed issues at the location: suppressing
+// processors hide issues by setting Suppression with --show-suppressed.
+func (e *issuesExplainer) issuesAt(issues []result.Issue) []result.Issue {
+	var ret []result.Issue
+	for i := range issues {
+		if issues[i].Suppression == nil && e.matches(&issues[i]) {
+			ret = append(ret, issues[i])
+		}
+	}
+	return ret
+}
+
+// trackReported starts tracking of issues at the location reported by a linter,
+// it returns indices of the tracked issues.
+func (e *issuesExplainer) trackReported(issues []result.Issue) []int {
+	var tracked []int
+	for _, i := range e.issuesAt(issues) {
+		tracked = append(tracked, len(e.issues))
+		e.issues = append(e.issues, explainedIssue{issue: i})
+	}
+	return tracked
+}
+
+// explainKey doesn't depend on a path: paths are changed by processors.
+func explainKey(i *result.Issue) string {
+	return fmt.Sprintf("%s|%s|%d|%s", i.FromLinter, i.RuleID, i.Column(), i.Text)
+}
+
+// trackProcessor finds tracked issues filtered out by the processor and returns
+// indices of still tracked issues. Processors keep order of issues, processors
+// changing issues don't filter them out.
+func (e *issuesExplainer) trackProcessor(p processors.Processor, tracked []int, issues, newIssues []result.Issue) []int {
+	before, after := e.issuesAt(issues), e.issuesAt(newIssues)
+	if len(before) == len(after) {
+		return tracked
+	}
+
+	var stillTracked []int
+	j := 0
+	for k := range before {
+		if j < len(after) && explainKey(&after[j]) == explainKey(&before[k]) {
+			stillTracked = append(stillTracked, tracked[k])
+			j++
+			continue
+		}
+
+		ei := &e.issues[tracked[k]]
+		ei.processor = p.Name()
+		if explainer, ok := p.(processors.Explainer); ok {
+			ei.reason = explainer.Explain(&before[k])
+		}
+	}
+
+	return stillTracked
+}
+
+func (e *issuesExplainer) print(w io.Writer) {
+	if len(e.issues) == 0 {
+		fmt.Fprintf(w, "No issues were reported by linters at %s\n", e.location)
+		return
+	}
+
+	sort.SliceStable(e.issues, func(i, j int) bool {
+		if e.issues[i].issue.FromLinter != e.issues[j].issue.FromLinter {
+			return e.issues[i].issue.FromLinter < e.issues[j].issue.FromLinter
+		}
+		return e.issues[i].issue.Column() < e.issues[j].issue.Column()
+	})
+
+	fmt.Fprintf(w, "Issues reported by linters at %s:\n", e.location)
+	for _, ei := range e.issues {
+		fmt.Fprintf(w, "  %s: %s\n", ei.issue.QualifiedRuleID(), ei.issue.Text)
+		switch {
+		case ei.processor == "":
+			fmt.Fprintf(w, "    shown\n")
+		case ei.reason == "":
+			fmt.Fprintf(w, "    hidden by %s\n", ei.processor)
+		default:
+			fmt.Fprintf(w, "    hidden by %s: %s\n", ei.processor, ei.reason)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"bytes"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
+)
+
+func TestIssuesExplainer(t *testing.T) {
+	newIssue := func(linter string, line int, text string) result.Issue {
+		return result.Issue{
+			FromLinter: linter,
+			Text:       text,
+			Pos:        token.Position{Filename: "a.go", Line: line},
+		}
+	}
+
+	e, err := newIssuesExplainer("a.go:2")
+	require.NoError(t, err)
+
+	cfg := &config.Config{}
+	r := Runner{
+		Processors: []processors.Processor{
+			processors.NewExcludePatterns([]string{"^unused", "^excluded"}, nil, nil),
+			processors.NewMaxSameIssues(1, logutils.NewStderrLog(""), cfg),
+		},
+		Log:       logutils.NewStderrLog(""),
+		explainer: e,
+	}
+
+	issues := []result.Issue{
+		newIssue("govet", 2, "excluded"),
+		newIssue("govet", 1, "same"),
+		newIssue("golint", 2, "same"),
+		newIssue("golint", 2, "shown"),
+		newIssue("golint", 3, "excluded"),
+	}
+	processed := r.processIssues(issues, timeutils.NewStopwatch("processing", r.Log), timeutils.NewProfiler(),
+		map[string]processorStat{})
+	assert.Equal(t, []result.Issue{issues[1], issues[3]}, processed)
+
+	var out bytes.Buffer
+	e.print(&out)
+	assert.Equal(t, `Issues reported by linters at a.go:2:
+  golint: same
+    hidden by max_same_issues: only 1 issues with the same text are reported, use --max-same-issues
+  golint: shown
+    shown
+  govet: excluded
+    hidden by exclude: text matches exclude pattern "^excluded"
+`, out.String())
+}
+
+func TestIssuesExplainerInvalidLocation(t *testing.T) {
+	for _, location := range []string{"a.go", "a.go:", "a.go:b", "a.go:0"} {
+		_, err := newIssuesExplainer(location)
+		assert.Error(t, err, location)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
 )
 
 type Context struct {
@@ -40,6 +41,8 @@ type Context struct {
 	PkgCache         *pkgcache.Cache
 	LoadGuard        *load.Guard
 	NeedWholeProgram bool
+
+	Profiler *timeutils.Profiler
 }
 
 func (c *Context) Settings() *config.LintersSettings {
@@ -11,3 +11,15 @@ type Linter interface {
 	Name() string
 	Desc() string
 }
+
+// RuleIDsProvider is implemented by linters which know all rule IDs
+// (result.Issue.RuleID) of their issues, e.g. names of analyzers.
+type RuleIDsProvider interface {
+	RuleIDs() []string
+}
+
+// ChildLinterNamesProvider is implemented by linters running other linters,
+// e.g. megacheck: their issues have names of the children in FromLinter.
+type ChildLinterNamesProvider interface {
+	ChildLinterNames() []string
+}
@@ -0,0 +1,80 @@
+package lintersdb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/golinters"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+)
+
+// loadCustomLinters makes configs of linters from the custom-linters config
+// section. Go plugins are loaded here to fail early on invalid plugins.
+func (m *Manager) loadCustomLinters() error {
+	if m.cfg == nil {
+		return nil
+	}
+
+	var names []string
+	for name := range m.cfg.CustomLinters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	builtinNames := map[string]bool{}
+	for _, lc := range m.GetAllSupportedLinterConfigs() {
+		for _, name := range lc.AllNames() {
+			builtinNames[name] = true
+		}
+	}
+	for name := range m.GetMetaLinters() {
+		builtinNames[name] = true
+	}
+
+	allPresets := m.allPresetsSet()
+	for _, name := range names {
+		if builtinNames[name] {
+			return fmt.Errorf("custom linter %q has the same name as a builtin linter", name)
+		}
+
+		// the config isn't validated by the config reader for the library API
+		settings := m.cfg.CustomLinters[name]
+		if err := settings.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid custom linter %q", name)
+		}
+		for _, p := range settings.Presets {
+			if !allPresets[p] {
+				return fmt.Errorf("custom linter %q has unknown preset %q", name, p)
+			}
+		}
+
+		lc, err := newCustomLinterConfig(name, &settings)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load custom linter %q", name)
+		}
+		m.customLinters = append(m.customLinters, lc)
+	}
+
+	return nil
+}
+
+func newCustomLinterConfig(name string, settings *config.CustomLinterSettings) (*linter.Config, error) {
+	var lc *linter.Config
+	if settings.IsPlugin() {
+		lnt, err := golinters.NewCustomPlugin(name, settings)
+		if err != nil {
+			return nil, err
+		}
+		lc = linter.NewConfig(lnt).WithLoadForGoAnalysis()
+	} else {
+		lc = linter.NewConfig(golinters.NewCustomExecutable(name, settings))
+		if settings.LoadMode == config.CustomLinterLoadModeTypes {
+			lc = lc.WithLoadTypeInfo()
+		}
+	}
+
+	return lc.WithPresets(settings.Presets...).WithURL(settings.OriginalURL), nil
+}
@@ -12,10 +12,15 @@ import (
 type Manager struct {
 	nameToLC map[string]*linter.Config
 	cfg      *config.Config
+
+	customLinters    []*linter.Config
+	customLintersErr error
 }
 
 func NewManager(cfg *config.Config) *Manager {
 	m := &Manager{cfg: cfg}
+	m.customLintersErr = m.loadCustomLinters()
+
 	nameToLC := make(map[string]*linter.Config)
 	for _, lc := range m.GetAllSupportedLinterConfigs() {
 		for _, name := range lc.AllNames() {
@@ -263,6 +268,7 @@ func (m Manager) GetAllSupportedLinterConfigs() []*linter.Config {
 			WithAutoFix().
 			WithURL("https://github.com/ultraware/whitespace"),
 	}
+	lcs = append(lcs, m.customLinters...)
 
 	isLocalRun := os.Getenv("GOLANGCI_COM_RUN") == ""
 	enabledByDefault := map[string]bool{
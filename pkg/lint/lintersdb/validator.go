@@ -17,6 +17,10 @@ func NewValidator(m *Manager) *Validator {
 	}
 }
 
+func (v Validator) validateCustomLinters(_ *config.Linters) error {
+	return v.m.customLintersErr
+}
+
 func (v Validator) validateLintersNames(cfg *config.Linters) error {
 	allNames := append([]string{}, cfg.Enable...)
 	allNames = append(allNames, cfg.Disable...)
@@ -84,6 +88,7 @@ func (v Validator) validateDisabledAndEnabledAtOneMoment(cfg *config.Linters) er
 
 func (v Validator) validateEnabledDisabledLintersConfig(cfg *config.Linters) error {
 	validators := []func(cfg *config.Linters) error{
+		v.validateCustomLinters, // before names validation: custom linters failed to load are unknown
 		v.validateLintersNames,
 		v.validatePresets,
 		v.validateAllDisableEnableOptions,
@@ -30,6 +30,7 @@ import (
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/timeutils"
 )
 
 type ContextLoader struct {
@@ -42,10 +43,14 @@ type ContextLoader struct {
 	fileCache   *fsutils.FileCache
 	pkgCache    *pkgcache.Cache
 	loadGuard   *load.Guard
+	profiler    *timeutils.Profiler
+
+	parsedFiles *astcache.ParsedFiles // kept between loads only by KeepParsedFiles
 }
 
 func NewContextLoader(cfg *config.Config, log logutils.Log, goenv *goutil.Env,
-	lineCache *fsutils.LineCache, fileCache *fsutils.FileCache, pkgCache *pkgcache.Cache, loadGuard *load.Guard) *ContextLoader {
+	lineCache *fsutils.LineCache, fileCache *fsutils.FileCache, pkgCache *pkgcache.Cache, loadGuard *load.Guard,
+	profiler *timeutils.Profiler) *ContextLoader {
 	return &ContextLoader{
 		cfg:         cfg,
 		log:         log,
@@ -56,20 +61,44 @@ func NewContextLoader(cfg *config.Config, log logutils.Log, goenv *goutil.Env,
 		fileCache:   fileCache,
 		pkgCache:    pkgCache,
 		loadGuard:   loadGuard,
+		profiler:    profiler,
+	}
+}
+
+// KeepParsedFiles makes the loader keep parsed files between loads of
+// long-running servers: changed files must be passed to ForgetFiles.
+func (cl *ContextLoader) KeepParsedFiles() {
+	cl.parsedFiles = astcache.NewParsedFiles()
+}
+
+// ForgetFiles drops state kept for the files given by absolute paths.
+func (cl *ContextLoader) ForgetFiles(absPaths []string) {
+	if cl.parsedFiles != nil {
+		cl.parsedFiles.Forget(absPaths)
 	}
 }
 
-func (cl *ContextLoader) prepareBuildContext() {
+// makeBuildContext returns a build context of the run for linters using
+// go/build: build.Default isn't changed, concurrent runs can have other tags.
+func (cl *ContextLoader) makeBuildContext() *build.Context {
+	ctx := build.Default
 	// Set GOROOT to have working cross-compilation: cross-compiled binaries
 	// have invalid GOROOT. XXX: can't use runtime.GOROOT().
+	if goroot := cl.goenv.Get(goutil.EnvGoRoot); goroot != "" {
+		ctx.GOROOT = goroot
+	}
+	ctx.BuildTags = cl.cfg.Run.BuildTags
+	return &ctx
+}
+
+// makeEnv returns an environment for go list with GOROOT by the same reason as makeBuildContext.
+func (cl *ContextLoader) makeEnv() []string {
 	goroot := cl.goenv.Get(goutil.EnvGoRoot)
-	if goroot == "" {
-		return
+	if goroot == "" || os.Getenv("GOROOT") == goroot {
+		return nil // the environment of the process
 	}
 
-	os.Setenv("GOROOT", goroot)
-	build.Default.GOROOT = goroot
-	build.Default.BuildTags = cl.cfg.Run.BuildTags
+	return append(os.Environ(), "GOROOT="+goroot)
 }
 
 func (cl *ContextLoader) makeFakeLoaderPackageInfo(pkg *packages.Package) *loader.PackageInfo {
@@ -259,28 +288,45 @@ func (cl *ContextLoader) parseLoadedPackagesErrors(pkgs []*packages.Package) err
 	return nil
 }
 
-func (cl *ContextLoader) loadPackages(ctx context.Context, loadMode packages.LoadMode) ([]*packages.Package, error) {
-	defer func(startedAt time.Time) {
-		cl.log.Infof("Go packages loading at mode %s took %s", stringifyLoadMode(loadMode), time.Since(startedAt))
-	}(time.Now())
-
-	cl.prepareBuildContext()
-
+func (cl *ContextLoader) makeLoadConfig(ctx context.Context, loadMode packages.LoadMode) (*packages.Config, error) {
 	buildFlags, err := cl.makeBuildFlags()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to make build flags for go list")
 	}
 
-	conf := &packages.Config{
+	return &packages.Config{
 		Mode:       loadMode,
 		Tests:      cl.cfg.Run.AnalyzeTests,
 		Context:    ctx,
+		Env:        cl.makeEnv(),
 		BuildFlags: buildFlags,
 		Logf:       cl.debugf,
-		//TODO: use fset, parsefile, overlay
+		Overlay:    cl.fileCache.Overlay(),
+		//TODO: use fset, parsefile
+	}, nil
+}
+
+func (cl *ContextLoader) loadPackages(ctx context.Context, loadMode packages.LoadMode) ([]*packages.Package, error) {
+	defer func(startedAt time.Time) {
+		cl.log.Infof("Go packages loading at mode %s took %s", stringifyLoadMode(loadMode), time.Since(startedAt))
+	}(time.Now())
+
+	conf, err := cl.makeLoadConfig(ctx, loadMode)
+	if err != nil {
+		return nil, err
 	}
 
 	args := cl.buildArgs()
+	if cl.cfg.Issues.DiffAffectedOnly {
+		if args, err = cl.buildAffectedArgs(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if cl.cfg.Run.Shard != "" {
+		if args, err = cl.buildShardArgs(ctx, args); err != nil {
+			return nil, err
+		}
+	}
 	cl.debugf("Built loader args are %s", args)
 	pkgs, err := packages.Load(conf, args...)
 	if err != nil {
@@ -372,8 +418,17 @@ func needSSA(linters []*linter.Config) bool {
 
 //nolint:gocyclo
 func (cl *ContextLoader) Load(ctx context.Context, linters []*linter.Config) (*linter.Context, error) {
+	if cl.cfg.Run.MaxMemory > 0 {
+		const MB = 1024 * 1024
+		cl.loadGuard.SetMemoryLimit(uint64(cl.cfg.Run.MaxMemory) * MB)
+	}
+
 	loadMode := cl.findLoadMode(linters)
-	pkgs, err := cl.loadPackages(ctx, loadMode)
+	var pkgs []*packages.Package
+	var err error
+	cl.profiler.Track(timeutils.ProfileCategoryLoading, "go/packages loading", 0, func() {
+		pkgs, err = cl.loadPackages(ctx, loadMode)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -386,16 +441,23 @@ func (cl *ContextLoader) Load(ctx context.Context, linters []*linter.Config) (*l
 
 	var prog *loader.Program
 	if loadMode&packages.NeedTypes != 0 {
-		prog = cl.makeFakeLoaderProgram(deduplicatedPkgs)
+		cl.profiler.Track(timeutils.ProfileCategoryLoading, "loader program building", 0, func() {
+			prog = cl.makeFakeLoaderProgram(deduplicatedPkgs)
+		})
 	}
 
 	var ssaProg *ssa.Program
 	if needSSA(linters) {
-		ssaProg = cl.buildSSAProgram(deduplicatedPkgs)
+		cl.profiler.Track(timeutils.ProfileCategoryLoading, "SSA building", 0, func() {
+			ssaProg = cl.buildSSAProgram(deduplicatedPkgs)
+		})
 	}
 
 	astLog := cl.log.Child("astcache")
-	astCache, err := astcache.LoadFromPackages(deduplicatedPkgs, astLog)
+	var astCache *astcache.Cache
+	cl.profiler.Track(timeutils.ProfileCategoryLoading, "astcache building", 0, func() {
+		astCache, err = astcache.LoadFromPackages(deduplicatedPkgs, cl.fileCache.Overlay(), cl.parsedFiles, astLog)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -410,8 +472,8 @@ func (cl *ContextLoader) Load(ctx context.Context, linters []*linter.Config) (*l
 		Program:    prog,
 		SSAProgram: ssaProg,
 		LoaderConfig: &loader.Config{
-			Cwd:   "",  // used by depguard and fallbacked to os.Getcwd
-			Build: nil, // used by depguard and megacheck and fallbacked to build.Default
+			Cwd:   "",                    // used by depguard and fallbacked to os.Getcwd
+			Build: cl.makeBuildContext(), // used by depguard
 		},
 		Cfg:              cl.cfg,
 		ASTCache:         astCache,
@@ -420,6 +482,7 @@ func (cl *ContextLoader) Load(ctx context.Context, linters []*linter.Config) (*l
 		LineCache:        cl.lineCache,
 		PkgCache:         cl.pkgCache,
 		LoadGuard:        cl.loadGuard,
+		Profiler:         cl.profiler,
 		NeedWholeProgram: loadMode&packages.NeedDeps != 0 && loadMode&packages.NeedTypesInfo != 0,
 	}
 
@@ -454,3 +517,24 @@ func separateNotCompilingPackages(lintCtx *linter.Context) {
 	}
 	lintCtx.OriginalPackages = goodOriginalPkgs
 }
+
+// LoadASTCache loads only files of packages matching run args and parses them:
+// it's enough to find comments in the files. It returns the loaded packages too.
+func (cl *ContextLoader) LoadASTCache(ctx context.Context) ([]*packages.Package, *astcache.Cache, error) {
+	pkgs, err := cl.loadPackages(ctx, packages.NeedName|packages.NeedFiles)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deduplicatedPkgs := cl.filterDuplicatePackages(pkgs)
+	if len(deduplicatedPkgs) == 0 {
+		return nil, nil, exitcodes.ErrNoGoFiles
+	}
+
+	astCache, err := astcache.LoadFromPackages(deduplicatedPkgs, cl.fileCache.Overlay(), cl.parsedFiles,
+		cl.log.Child("astcache"))
+	if err != nil {
+		return nil, nil, err
+	}
+	return deduplicatedPkgs, astCache, nil
+}
@@ -0,0 +1,25 @@
+package lint
+
+import (
+	"go/build"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/goutil"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestMakeBuildContext(t *testing.T) {
+	defaultTags := build.Default.BuildTags
+
+	cfg := config.NewDefault()
+	cfg.Run.BuildTags = []string{"integration"}
+	log := logutils.NewStderrLog("")
+	cl := NewContextLoader(cfg, log, goutil.NewEnv(log), nil, nil, nil, nil, nil)
+
+	ctx := cl.makeBuildContext()
+	assert.Equal(t, []string{"integration"}, ctx.BuildTags)
+	assert.Equal(t, defaultTags, build.Default.BuildTags) // concurrent runs can have other tags
+}
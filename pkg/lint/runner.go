@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -28,19 +29,26 @@ import (
 type Runner struct {
 	Processors []processors.Processor
 	Log        logutils.Log
+
+	explainer      *issuesExplainer
+	unusedExcludes *processors.UnusedExcludes
 }
 
 func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log, goenv *goutil.Env,
 	lineCache *fsutils.LineCache, dbManager *lintersdb.Manager) (*Runner, error) {
 	icfg := cfg.Issues
-	excludePatterns := icfg.ExcludePatterns
+	var defaultExcludePatterns []string
 	if icfg.UseDefaultExcludes {
-		excludePatterns = append(excludePatterns, config.GetDefaultExcludePatternsStrings()...)
+		defaultExcludePatterns = config.GetDefaultExcludePatternsStrings()
 	}
 
-	var excludeTotalPattern string
-	if len(excludePatterns) != 0 {
-		excludeTotalPattern = fmt.Sprintf("(%s)", strings.Join(excludePatterns, "|"))
+	// unused excludes are known only if all issues are processed
+	var unusedExcludes *processors.UnusedExcludes
+	if isFullRun(cfg) {
+		unusedExcludes = &processors.UnusedExcludes{
+			Log:  log.Child("unused_excludes"),
+			Fail: icfg.FailOnUnusedExcludes,
+		}
 	}
 
 	skipFilesProcessor, err := processors.NewSkipFiles(cfg.Run.SkipFiles)
@@ -58,41 +66,162 @@ func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log, g
 	}
 
 	var excludeRules []processors.ExcludeRule
+	var generatedBy []string // of rules in effect: issues in files matching them aren't excluded as generated
 	for _, r := range icfg.ExcludeRules {
-		excludeRules = append(excludeRules, processors.ExcludeRule{
-			Text:    r.Text,
-			Source:  r.Source,
-			Path:    r.Path,
-			Linters: r.Linters,
-		})
+		rule := processors.ExcludeRule{
+			Text:        r.Text,
+			Source:      r.Source,
+			Path:        r.Path,
+			RuleID:      r.RuleID,
+			Package:     r.Package,
+			Function:    r.Function,
+			GeneratedBy: r.GeneratedBy,
+			Linters:     r.Linters,
+			SuppressionExpiry: processors.SuppressionExpiry{
+				Expires: r.Expires,
+				Owner:   r.Owner,
+			},
+		}
+		if rule.GeneratedBy != "" && !rule.IsExpired(time.Now()) {
+			generatedBy = append(generatedBy, rule.GeneratedBy)
+		}
+		excludeRules = append(excludeRules, rule)
+	}
+
+	// generated-by of exclude rules finds generated files by the configured
+	// mode: the disabled one only stops excluding all their issues
+	generatedByCfg := icfg.Generated
+	if generatedByCfg.Mode == config.GeneratedModeDisabled {
+		generatedByCfg.Mode = config.GeneratedModeLax
+	}
+
+	procs := []processors.Processor{
+		processors.NewCgo(goenv),
+		processors.NewFilenameUnadjuster(astCache, log.Child("filename_unadjuster")), // must go after Cgo
+		processors.NewPathPrettifier(), // must be before diff, nolint and exclude autogenerated processor at least
+		skipFilesProcessor,
+		skipDirsProcessor, // must be after path prettifier
+
+		processors.NewAutogeneratedExclude(astCache, processors.NewGeneratedDetector(&icfg.Generated), generatedBy),
+		processors.NewIdentifierMarker(), // must be before exclude because users see already marked output and configure excluding by it
+		processors.NewExcludePatterns(icfg.ExcludePatterns, defaultExcludePatterns, unusedExcludes),
+		processors.NewExcludeRules(excludeRules, lineCache, astCache, processors.NewGeneratedDetector(&generatedByCfg),
+			log.Child("exclude_rules"), unusedExcludes),
+		processors.NewNolint(astCache, log.Child("nolint"), dbManager),
+
+		processors.NewUniqByLine(cfg),
+		processors.NewDiff(icfg.Diff || icfg.DiffAffectedOnly, icfg.DiffFromRevision, icfg.DiffPatchFilePath,
+			icfg.DiffStaged),
+		processors.NewMaxPerFileFromLinter(cfg),
+		processors.NewMaxSameIssues(icfg.MaxSameIssues, log.Child("max_same_issues"), cfg),
+		processors.NewMaxFromLinter(icfg.MaxIssuesPerLinter, log.Child("max_from_linter"), cfg),
+		processors.NewSourceCode(lineCache, log.Child("source_code")),
+		processors.NewPathShortener(),
+	}
+	if cfg.Run.Shard != "" {
+		procs = withoutCrossShardProcessors(procs)
+	}
+	if icfg.ShowSuppressed {
+		procs = withSuppressors(procs)
+	}
+
+	var explainer *issuesExplainer
+	if cfg.Run.ExplainLocation != "" {
+		if explainer, err = newIssuesExplainer(cfg.Run.ExplainLocation); err != nil {
+			return nil, err
+		}
 	}
 
 	return &Runner{
-		Processors: []processors.Processor{
-			processors.NewCgo(goenv),
-			processors.NewFilenameUnadjuster(astCache, log.Child("filename_unadjuster")), // must go after Cgo
-			processors.NewPathPrettifier(), // must be before diff, nolint and exclude autogenerated processor at least
-			skipFilesProcessor,
-			skipDirsProcessor, // must be after path prettifier
-
-			processors.NewAutogeneratedExclude(astCache),
-			processors.NewIdentifierMarker(), // must be before exclude because users see already marked output and configure excluding by it
-			processors.NewExclude(excludeTotalPattern),
-			processors.NewExcludeRules(excludeRules, lineCache, log.Child("exclude_rules")),
-			processors.NewNolint(astCache, log.Child("nolint"), dbManager),
-
-			processors.NewUniqByLine(cfg),
-			processors.NewDiff(icfg.Diff, icfg.DiffFromRevision, icfg.DiffPatchFilePath),
-			processors.NewMaxPerFileFromLinter(cfg),
-			processors.NewMaxSameIssues(icfg.MaxSameIssues, log.Child("max_same_issues"), cfg),
-			processors.NewMaxFromLinter(icfg.MaxIssuesPerLinter, log.Child("max_from_linter"), cfg),
-			processors.NewSourceCode(lineCache, log.Child("source_code")),
-			processors.NewPathShortener(),
-		},
-		Log: log,
+		Processors:     procs,
+		Log:            log,
+		explainer:      explainer,
+		unusedExcludes: unusedExcludes,
 	}, nil
 }
 
+// isFullRun returns true if issues of all packages are processed: not only
+// changed, piped, re-analyzed or other packages than the default ./....
+func isFullRun(cfg *config.Config) bool {
+	icfg := &cfg.Issues
+	isDiff := icfg.Diff || icfg.DiffAffectedOnly || icfg.DiffFromRevision != "" || icfg.DiffPatchFilePath != "" ||
+		icfg.DiffStaged
+	if isDiff || cfg.Run.Shard != "" || cfg.Run.StdinFilename != "" || cfg.Run.Watch || cfg.Run.IsIncremental {
+		return false
+	}
+
+	args := cfg.Run.Args
+	return len(args) == 0 || (len(args) == 1 && args[0] == "./...")
+}
+
+// linterNames returns names of linters reporting issues by the linters.
+func linterNames(linters []*linter.Config) []string {
+	var ret []string
+	for _, lc := range linters {
+		ret = append(ret, lc.Name())
+		if p, ok := lc.Linter.(linter.ChildLinterNamesProvider); ok {
+			ret = append(ret, p.ChildLinterNames()...)
+		}
+	}
+	return ret
+}
+
+// NewCrossShardProcessors returns processors needing issues of all packages
+// in the order of a not sharded run: sharded runs skip them, merge
+// of shard reports applies them.
+func NewCrossShardProcessors(cfg *config.Config, log logutils.Log) []processors.Processor {
+	return []processors.Processor{
+		processors.NewUniqByLine(cfg),
+		processors.NewMaxPerFileFromLinter(cfg),
+		processors.NewMaxSameIssues(cfg.Issues.MaxSameIssues, log.Child("max_same_issues"), cfg),
+		processors.NewMaxFromLinter(cfg.Issues.MaxIssuesPerLinter, log.Child("max_from_linter"), cfg),
+	}
+}
+
+func withoutCrossShardProcessors(procs []processors.Processor) []processors.Processor {
+	var ret []processors.Processor
+	for _, p := range procs {
+		switch p.(type) {
+		case *processors.UniqByLine, *processors.MaxPerFileFromLinter,
+			*processors.MaxSameIssues, *processors.MaxFromLinter:
+			continue
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
+// withSuppressors makes processors filtering out issues on user's demand
+// keep them as suppressed issues.
+func withSuppressors(procs []processors.Processor) []processors.Processor {
+	var ret []processors.Processor
+	for _, p := range procs {
+		var kind string
+		switch p.(type) {
+		case *processors.Exclude:
+			kind = "exclude"
+		case *processors.ExcludeRules:
+			kind = "exclude-rule"
+		case *processors.Nolint:
+			kind = "nolint"
+		case *processors.AutogeneratedExclude:
+			kind = "generated"
+		case *processors.SkipDirs:
+			kind = "skip-dirs"
+		case *processors.SkipFiles:
+			kind = "skip-files"
+		case *processors.Diff:
+			kind = "diff"
+		}
+
+		if kind != "" {
+			p = processors.NewSuppressor(p, kind)
+		}
+		ret = append(ret, p)
+	}
+	return ret
+}
+
 type lintRes struct {
 	linter *linter.Config
 	err    error
@@ -148,7 +277,9 @@ func (r Runner) runWorker(ctx context.Context, lintCtx *linter.Context,
 			var issues []result.Issue
 			var err error
 			sw.TrackStage(lc.Name(), func() {
-				issues, err = r.runLinterSafe(ctx, lintCtx, lc)
+				lintCtx.Profiler.Track(timeutils.ProfileCategoryLinters, lc.Name(), timeutils.WorkerID(ctx), func() {
+					issues, err = r.runLinterSafe(ctx, lintCtx, lc)
+				})
 			})
 			lintResultsCh <- lintRes{
 				linter: lc,
@@ -192,7 +323,12 @@ func getSortedLintersConfigs(linters []*linter.Config) []*linter.Config {
 
 func (r *Runner) runWorkers(ctx context.Context, lintCtx *linter.Context, linters []*linter.Config) <-chan lintRes {
 	tasksCh := make(chan *linter.Config, len(linters))
-	lintResultsCh := make(chan lintRes, len(linters))
+	lintResultsCh := make(chan lintRes, len(linters)+1)
+
+	// expired suppressions are reported like issues of a linter
+	if issues := getExpiredSuppressionsIssues(lintCtx.Cfg, lintCtx.ASTCache, time.Now()); len(issues) != 0 {
+		lintResultsCh <- lintRes{issues: issues}
+	}
 	var wg sync.WaitGroup
 
 	workersFinishTimes := make([]time.Time, lintCtx.Cfg.Run.Concurrency)
@@ -202,7 +338,7 @@ func (r *Runner) runWorkers(ctx context.Context, lintCtx *linter.Context, linter
 		go func(i int) {
 			defer wg.Done()
 			name := fmt.Sprintf("worker.%d", i+1)
-			r.runWorker(ctx, lintCtx, tasksCh, lintResultsCh, name)
+			r.runWorker(timeutils.WithWorkerID(ctx, i+1), lintCtx, tasksCh, lintResultsCh, name)
 			workersFinishTimes[i] = time.Now()
 		}(i)
 	}
@@ -228,7 +364,7 @@ type processorStat struct {
 	outCount int
 }
 
-func (r Runner) processLintResults(inCh <-chan lintRes) <-chan lintRes {
+func (r Runner) processLintResults(inCh <-chan lintRes, profiler *timeutils.Profiler) <-chan lintRes {
 	outCh := make(chan lintRes, 64)
 
 	go func() {
@@ -246,7 +382,7 @@ func (r Runner) processLintResults(inCh <-chan lintRes) <-chan lintRes {
 
 			if len(res.issues) != 0 {
 				issuesBefore += len(res.issues)
-				res.issues = r.processIssues(res.issues, sw, statPerProcessor)
+				res.issues = r.processIssues(res.issues, sw, profiler, statPerProcessor)
 				issuesAfter += len(res.issues)
 				outCh <- res
 			}
@@ -257,7 +393,7 @@ func (r Runner) processLintResults(inCh <-chan lintRes) <-chan lintRes {
 		for _, p := range r.Processors {
 			p := p
 			sw.TrackStage(p.Name(), func() {
-				p.Finish()
+				profiler.Track(timeutils.ProfileCategoryProcessors, p.Name()+" finish", 0, p.Finish)
 			})
 		}
 
@@ -266,6 +402,10 @@ func (r Runner) processLintResults(inCh <-chan lintRes) <-chan lintRes {
 		}
 		r.printPerProcessorStat(statPerProcessor)
 		sw.PrintStages()
+
+		if r.explainer != nil {
+			r.explainer.print(logutils.StdErr)
+		}
 	}()
 
 	return outCh
@@ -303,13 +443,19 @@ func collectIssues(resCh <-chan lintRes) <-chan result.Issue {
 }
 
 func (r Runner) Run(ctx context.Context, linters []*linter.Config, lintCtx *linter.Context) <-chan result.Issue {
+	if r.unusedExcludes != nil {
+		r.unusedExcludes.SetEnabledLinters(linterNames(linters))
+	}
+
 	lintResultsCh := r.runWorkers(ctx, lintCtx, linters)
-	processedLintResultsCh := r.processLintResults(lintResultsCh)
+	processedLintResultsCh := r.processLintResults(lintResultsCh, lintCtx.Profiler)
 	if ctx.Err() != nil {
 		// XXX: always process issues, even if timeout occurred
 		finishedLintersN := 0
-		for range processedLintResultsCh {
-			finishedLintersN++
+		for res := range processedLintResultsCh {
+			if res.linter != nil { // not expired suppressions
+				finishedLintersN++
+			}
 		}
 
 		r.Log.Errorf("%d/%d linters finished: deadline exceeded",
@@ -319,13 +465,22 @@ func (r Runner) Run(ctx context.Context, linters []*linter.Config, lintCtx *lint
 	return collectIssues(processedLintResultsCh)
 }
 
-func (r *Runner) processIssues(issues []result.Issue, sw *timeutils.Stopwatch, statPerProcessor map[string]processorStat) []result.Issue {
+func (r *Runner) processIssues(issues []result.Issue, sw *timeutils.Stopwatch, profiler *timeutils.Profiler,
+	statPerProcessor map[string]processorStat) []result.Issue {
+	var explained []int
+	if r.explainer != nil {
+		explained = r.explainer.trackReported(issues)
+	}
+
 	for _, p := range r.Processors {
 		var newIssues []result.Issue
 		var err error
 		p := p
 		sw.TrackStage(p.Name(), func() {
+			span := profiler.StartSpan(timeutils.ProfileCategoryProcessors, p.Name(), 0)
+			span.SetArg("issues", strconv.Itoa(len(issues)))
 			newIssues, err = p.Process(issues)
+			span.End()
 		})
 
 		if err != nil {
@@ -335,6 +490,9 @@ func (r *Runner) processIssues(issues []result.Issue, sw *timeutils.Stopwatch, s
 			stat.inCount += len(issues)
 			stat.outCount += len(newIssues)
 			statPerProcessor[p.Name()] = stat
+			if r.explainer != nil {
+				explained = r.explainer.trackProcessor(p, explained, issues, newIssues)
+			}
 			issues = newIssues
 		}
 
@@ -0,0 +1,49 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/golinters"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+)
+
+func TestIsFullRun(t *testing.T) {
+	cfg := &config.Config{}
+	assert.True(t, isFullRun(cfg))
+
+	cfg.Run.Args = []string{"./..."}
+	assert.True(t, isFullRun(cfg))
+
+	for _, modify := range []func(cfg *config.Config){
+		func(cfg *config.Config) { cfg.Run.Args = []string{"./pkg/..."} },
+		func(cfg *config.Config) { cfg.Run.Args = []string{"./...", "./cmd/..."} },
+		func(cfg *config.Config) { cfg.Run.StdinFilename = "main.go" },
+		func(cfg *config.Config) { cfg.Run.Watch = true },
+		func(cfg *config.Config) { cfg.Run.IsIncremental = true },
+		func(cfg *config.Config) { cfg.Run.Shard = "1/2" },
+		func(cfg *config.Config) { cfg.Issues.Diff = true },
+		func(cfg *config.Config) { cfg.Issues.DiffStaged = true },
+	} {
+		cfg := &config.Config{}
+		modify(cfg)
+		assert.False(t, isFullRun(cfg), "%+v", cfg.Run)
+	}
+}
+
+func TestLinterNames(t *testing.T) {
+	megacheck, err := golinters.MegacheckMetalinter{}.BuildLinterConfig([]string{
+		golinters.MegacheckGosimpleName, golinters.MegacheckUnusedName,
+	})
+	assert.NoError(t, err)
+
+	names := linterNames([]*linter.Config{
+		linter.NewConfig(golinters.Deadcode{}),
+		megacheck,
+	})
+	assert.Equal(t, []string{
+		"deadcode", golinters.MegacheckParentName, golinters.MegacheckGosimpleName, golinters.MegacheckUnusedName,
+	}, names)
+}
@@ -0,0 +1,139 @@
+package lint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+)
+
+// ErrEmptyShard is returned when there are fewer package directories than
+// shards and no package gets into the shard: there is nothing to analyze.
+var ErrEmptyShard = errors.New("no packages are in the shard")
+
+// Shard is a part of packages analyzed by one of parallel runs.
+type Shard struct {
+	Index int // from 1 to Count
+	Count int
+}
+
+// ParseShard parses a shard in the format i/n, e.g. 2/4.
+func ParseShard(s string) (*Shard, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid shard %q: must be in the format i/n", s)
+	}
+
+	index, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid shard index %q", parts[0])
+	}
+	count, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid shard count %q", parts[1])
+	}
+	if count < 1 || index < 1 || index > count {
+		return nil, fmt.Errorf("invalid shard %q: index must be from 1 to count", s)
+	}
+
+	return &Shard{Index: index, Count: count}, nil
+}
+
+// dirWeight is a total size of Go files of packages in a directory: packages of
+// a directory share files (e.g. test variants) and always get into one shard.
+type dirWeight struct {
+	dir  string
+	size int64
+}
+
+func getDirWeights(pkgs []*packages.Package) []dirWeight {
+	seenFiles := map[string]bool{}
+	sizes := map[string]int64{}
+	for _, pkg := range pkgs {
+		dir := pkgDir(pkg)
+		if dir == "" {
+			continue
+		}
+
+		if _, ok := sizes[dir]; !ok {
+			sizes[dir] = 0 // a directory without Go files still gets into a shard
+		}
+		for _, f := range pkg.GoFiles {
+			if seenFiles[f] {
+				continue
+			}
+			seenFiles[f] = true
+
+			if fi, err := os.Stat(f); err == nil {
+				sizes[dir] += fi.Size()
+			}
+		}
+	}
+
+	var ret []dirWeight
+	for dir, size := range sizes {
+		ret = append(ret, dirWeight{dir: dir, size: size})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].size != ret[j].size {
+			return ret[i].size > ret[j].size
+		}
+		return ret[i].dir < ret[j].dir
+	})
+	return ret
+}
+
+// shardDirs splits directories of packages between shards to make total sizes
+// of shards close: the biggest directories go first to the least loaded shard.
+// The split depends only on packages, so all shards compute the same split.
+func shardDirs(pkgs []*packages.Package, shard *Shard) []string {
+	shardSizes := make([]int64, shard.Count)
+	var dirs []string
+	for _, dw := range getDirWeights(pkgs) {
+		minShard := 0
+		for i := range shardSizes {
+			if shardSizes[i] < shardSizes[minShard] {
+				minShard = i
+			}
+		}
+
+		shardSizes[minShard] += dw.size
+		if minShard == shard.Index-1 {
+			dirs = append(dirs, dw.dir)
+		}
+	}
+
+	return dirs
+}
+
+// buildShardArgs restricts args to the directories of packages of the shard
+// from --shard.
+func (cl *ContextLoader) buildShardArgs(ctx context.Context, args []string) ([]string, error) {
+	shard, err := ParseShard(cl.cfg.Run.Shard)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := cl.loadPackagesGraph(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := shardDirs(g.pkgs, shard)
+	if len(dirs) == 0 {
+		return nil, ErrEmptyShard
+	}
+
+	shardArgs, err := dirsToArgs(dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	cl.log.Infof("Analyzing shard %d/%d: %d package directories", shard.Index, shard.Count, len(shardArgs))
+	return shardArgs, nil
+}
@@ -0,0 +1,57 @@
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestParseShard(t *testing.T) {
+	shard, err := ParseShard("2/4")
+	require.NoError(t, err)
+	assert.Equal(t, &Shard{Index: 2, Count: 4}, shard)
+
+	for _, s := range []string{"", "2", "0/4", "5/4", "a/4", "1/b", "1/2/3"} {
+		_, err = ParseShard(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestShardDirs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "shard")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	sizes := map[string]int{"a": 100, "b": 60, "c": 50, "d": 40, "e": 0}
+	var pkgs []*packages.Package
+	for name, size := range sizes {
+		pkgDir := filepath.Join(dir, name)
+		require.NoError(t, os.Mkdir(pkgDir, os.ModePerm))
+		file := filepath.Join(pkgDir, name+".go")
+		require.NoError(t, ioutil.WriteFile(file, []byte(strings.Repeat("x", size)), os.ModePerm))
+
+		pkg := &packages.Package{ID: name, GoFiles: []string{file}}
+		testPkg := &packages.Package{ID: name + " [" + name + ".test]", GoFiles: []string{file}}
+		pkgs = append(pkgs, pkg, testPkg)
+	}
+
+	shardOf := map[string]int{}
+	for i := 1; i <= 2; i++ {
+		for _, d := range shardDirs(pkgs, &Shard{Index: i, Count: 2}) {
+			rel, err := filepath.Rel(dir, d)
+			require.NoError(t, err)
+			_, dup := shardOf[rel]
+			assert.False(t, dup, "%s is in two shards", rel)
+			shardOf[rel] = i
+		}
+	}
+
+	// a=100 → 1, b=60 → 2, c=50 → 2 (110), d=40 → 1 (140), e=0 → 2
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 2, "d": 1, "e": 2}, shardOf)
+}
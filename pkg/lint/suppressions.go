@@ -0,0 +1,99 @@
+package lint
+
+import (
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
+)
+
+func getExpiredSuppressionsIssues(cfg *config.Config, astCache *astcache.Cache, now time.Time) []result.Issue {
+	var ret []result.Issue
+	for _, f := range astCache.GetAllValidFiles() {
+		for _, d := range processors.FindNolintDirectives(f.F, f.Fset) {
+			if !d.IsExpired(now) {
+				continue
+			}
+
+			ret = append(ret, result.Issue{
+				FromLinter: processors.ExpiredSuppressionsLinterName,
+				RuleID:     "expired",
+				Text: fmt.Sprintf("nolint directive expired on %s%s: fix suppressed issues or extend it",
+					d.Expires, formatOwner(d.Owner)),
+				Pos: d.Pos,
+			})
+		}
+	}
+
+	// Rules of the config don't depend on analyzed packages: only the first
+	// shard reports them, otherwise merge of shard reports has duplicates.
+	if cfg.Run.Shard != "" {
+		if shard, err := ParseShard(cfg.Run.Shard); err != nil || shard.Index != 1 {
+			return ret
+		}
+	}
+
+	var configLines []string
+	if cfg.Run.UsedConfigFile != "" {
+		if data, err := ioutil.ReadFile(cfg.Run.UsedConfigFile); err == nil {
+			configLines = strings.Split(string(data), "\n")
+		}
+	}
+
+	seenExpires := map[string]int{}
+	for idx, r := range cfg.Issues.ExcludeRules {
+		if r.Expires == "" {
+			continue
+		}
+		nth := seenExpires[r.Expires]
+		seenExpires[r.Expires]++
+
+		e := processors.SuppressionExpiry{Expires: r.Expires, Owner: r.Owner}
+		if !e.IsExpired(now) {
+			continue
+		}
+
+		ret = append(ret, result.Issue{
+			FromLinter: processors.ExpiredSuppressionsLinterName,
+			RuleID:     "expired",
+			Text: fmt.Sprintf("exclude rule issues.exclude-rules[%d] expired on %s%s: fix excluded issues or extend it",
+				idx, r.Expires, formatOwner(r.Owner)),
+			Pos: token.Position{
+				Filename: cfg.Run.UsedConfigFile,
+				Line:     findExpiresLine(configLines, r.Expires, nth),
+			},
+		})
+	}
+
+	return ret
+}
+
+func formatOwner(owner string) string {
+	if owner == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (owner %s)", owner)
+}
+
+// findExpiresLine returns a number of a line of the config with nth
+// expires option set to the date or 0 if there is no such line.
+func findExpiresLine(configLines []string, expires string, nth int) int {
+	re := regexp.MustCompile(`expires["']?\s*[:=]\s*["']?` + regexp.QuoteMeta(expires))
+	for i, line := range configLines {
+		if !re.MatchString(line) {
+			continue
+		}
+		if nth == 0 {
+			return i + 1
+		}
+		nth--
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestGetExpiredSuppressionsIssues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "expired_suppressions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	goFile := filepath.Join(dir, "a.go")
+	require.NoError(t, ioutil.WriteFile(goFile, []byte(`package a
+
+var a bool //nolint:gosec // expires:2019-09-30 owner:alice
+
+var b bool //nolint:gosec // expires:2019-10-01
+`), 0644))
+
+	configFile := filepath.Join(dir, ".golangci.yml")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(`issues:
+  exclude-rules:
+    - linters: [golint]
+      expires: 2019-01-01
+    - linters: [gosec]
+      expires: 2019-01-01
+      owner: bob
+    - linters: [lll]
+      expires: 2019-12-01
+`), 0644))
+
+	cfg := &config.Config{}
+	cfg.Run.UsedConfigFile = configFile
+	cfg.Issues.ExcludeRules = []config.ExcludeRule{
+		{Linters: []string{"golint"}, Expires: "2019-01-01"},
+		{Linters: []string{"gosec"}, Expires: "2019-01-01", Owner: "bob"},
+		{Linters: []string{"lll"}, Expires: "2019-12-01"},
+	}
+
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.Local)
+	issues := getExpiredSuppressionsIssues(cfg, astcache.LoadFromFilenames(logutils.NewStderrLog(""), goFile), now)
+	require.Len(t, issues, 3)
+
+	assert.Equal(t, goFile, issues[0].FilePath())
+	assert.Equal(t, 3, issues[0].Line())
+	assert.Equal(t, "nolint directive expired on 2019-09-30 (owner alice): fix suppressed issues or extend it",
+		issues[0].Text)
+
+	assert.Equal(t, configFile, issues[1].FilePath())
+	assert.Equal(t, 4, issues[1].Line())
+	assert.Equal(t, configFile, issues[2].FilePath())
+	assert.Equal(t, 6, issues[2].Line()) // the second rule with the same expiry date
+	assert.Equal(t, "exclude rule issues.exclude-rules[1] expired on 2019-01-01 (owner bob): fix excluded issues or extend it",
+		issues[2].Text)
+
+	cfg.Run.Shard = "1/2"
+	issues = getExpiredSuppressionsIssues(cfg, astcache.LoadFromFilenames(logutils.NewStderrLog(""), goFile), now)
+	assert.Len(t, issues, 3)
+
+	cfg.Run.Shard = "2/2"
+	issues = getExpiredSuppressionsIssues(cfg, astcache.LoadFromFilenames(logutils.NewStderrLog(""), goFile), now)
+	require.Len(t, issues, 1) // nolint directives are reported by shards of their packages
+	assert.Equal(t, goFile, issues[0].FilePath())
+}
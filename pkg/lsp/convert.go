@@ -0,0 +1,174 @@
+package lsp
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid URI %q", uri)
+	}
+	if u.Scheme != "file" {
+		return "", fmt.Errorf("unsupported URI scheme in %q", uri)
+	}
+
+	return filepath.FromSlash(u.Path), nil
+}
+
+// document is a text of an opened file: positions in issues are byte offsets
+// but LSP positions are offsets in UTF-16 code units, the text is needed to
+// convert them.
+type document struct {
+	lines []string
+}
+
+func newDocument(text string) document {
+	return document{lines: strings.Split(text, "\n")}
+}
+
+// line returns the text of the 1-based line without the line ending.
+func (d document) line(n int) string {
+	if n < 1 || n > len(d.lines) {
+		return ""
+	}
+
+	return strings.TrimSuffix(d.lines[n-1], "\r")
+}
+
+// position converts the 1-based line and the zero-based byte column.
+func (d document) position(line, byteCol int) Position {
+	if line < 1 {
+		return Position{}
+	}
+
+	text := d.line(line)
+	if byteCol > len(text) {
+		byteCol = len(text)
+	}
+	if byteCol < 0 {
+		byteCol = 0
+	}
+
+	return Position{Line: line - 1, Character: utf16Len(text[:byteCol])}
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		if r >= 0x10000 { // encoded by a surrogate pair
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// issueRange spans from the issue column (or the line start) to the end of
+// the last line of the issue.
+func issueRange(i *result.Issue, doc document) Range {
+	lr := i.GetLineRange()
+	if lr.From < 1 {
+		lr.From = 1
+	}
+	if lr.To < lr.From {
+		lr.To = lr.From
+	}
+
+	startCol := 0
+	if i.Column() > 0 {
+		startCol = i.Column() - 1
+	}
+
+	return Range{
+		Start: doc.position(lr.From, startCol),
+		End:   doc.position(lr.To, len(doc.line(lr.To))),
+	}
+}
+
+func issueToDiagnostic(i *result.Issue, doc document) Diagnostic {
+	return Diagnostic{
+		Range:    issueRange(i, doc),
+		Severity: severityWarning,
+		Code:     i.RuleID,
+		Source:   i.FromLinter,
+		Message:  i.Text,
+	}
+}
+
+// replacementEdit returns the edit applying the issue replacement the same
+// way as the fixer does or nil if the issue has no valid replacement.
+func replacementEdit(i *result.Issue, doc document) *TextEdit {
+	r := i.Replacement
+	if r == nil {
+		return nil
+	}
+
+	if r.Inline != nil {
+		line := doc.line(i.Line())
+		start, end := r.Inline.StartCol, r.Inline.StartCol+r.Inline.Length
+		if start < 0 || end > len(line) || start > end {
+			return nil
+		}
+
+		return &TextEdit{
+			Range: Range{
+				Start: doc.position(i.Line(), start),
+				End:   doc.position(i.Line(), end),
+			},
+			NewText: r.Inline.NewString,
+		}
+	}
+
+	lr := i.GetLineRange()
+	if lr.From < 1 {
+		return nil
+	}
+
+	edit := &TextEdit{
+		Range: Range{
+			Start: Position{Line: lr.From - 1},
+			End:   Position{Line: lr.To},
+		},
+	}
+	if !r.NeedOnlyDelete {
+		edit.NewText = strings.Join(r.NewLines, "\n") + "\n"
+	}
+	return edit
+}
+
+var nolintDirectiveRe = regexp.MustCompile(`//\s*nolint:[\w/-]+(?:,[\w/-]+)*`)
+
+// nolintEdit adds the linter to the //nolint directive of the issue line or
+// appends a new directive to the line.
+func nolintEdit(i *result.Issue, doc document) TextEdit {
+	line := doc.line(i.Line())
+
+	if locs := nolintDirectiveRe.FindAllStringIndex(line, -1); len(locs) != 0 {
+		end := locs[len(locs)-1][1]
+		pos := doc.position(i.Line(), end)
+		return TextEdit{
+			Range:   Range{Start: pos, End: pos},
+			NewText: "," + i.FromLinter,
+		}
+	}
+
+	pos := doc.position(i.Line(), len(line))
+	return TextEdit{
+		Range:   Range{Start: pos, End: pos},
+		NewText: " //nolint:" + i.FromLinter,
+	}
+}
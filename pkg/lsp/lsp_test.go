@@ -0,0 +1,162 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestDocumentPosition(t *testing.T) {
+	doc := newDocument("package p\r\n\nvar s = \"привет\" // 😀x\n")
+	assert.Equal(t, Position{Line: 0, Character: 9}, doc.position(1, 100))
+	assert.Equal(t, Position{Line: 2, Character: 16}, doc.position(3, len(`var s = "привет"`)))
+	assert.Equal(t, Position{Line: 2, Character: 22}, doc.position(3, len(`var s = "привет" // 😀`)))
+}
+
+func TestNolintEdit(t *testing.T) {
+	doc := newDocument("a := 1\nb := 2 //nolint:errcheck // reason\n")
+
+	i := result.Issue{FromLinter: "govet", Pos: token.Position{Line: 1}}
+	assert.Equal(t, " //nolint:govet", nolintEdit(&i, doc).NewText)
+	assert.Equal(t, Position{Line: 0, Character: 6}, nolintEdit(&i, doc).Range.Start)
+
+	i.Pos.Line = 2
+	assert.Equal(t, ",govet", nolintEdit(&i, doc).NewText)
+	assert.Equal(t, Position{Line: 1, Character: 24}, nolintEdit(&i, doc).Range.Start)
+}
+
+func TestReplacementEdit(t *testing.T) {
+	doc := newDocument("a := 1\nb := 2\n")
+
+	i := result.Issue{
+		Pos:         token.Position{Line: 2},
+		Replacement: &result.Replacement{Inline: &result.InlineFix{StartCol: 5, Length: 1, NewString: "3"}},
+	}
+	assert.Equal(t, &TextEdit{
+		Range:   Range{Start: Position{Line: 1, Character: 5}, End: Position{Line: 1, Character: 6}},
+		NewText: "3",
+	}, replacementEdit(&i, doc))
+
+	i.Replacement = &result.Replacement{NewLines: []string{"c := 3"}}
+	assert.Equal(t, &TextEdit{
+		Range:   Range{Start: Position{Line: 1}, End: Position{Line: 2}},
+		NewText: "c := 3\n",
+	}, replacementEdit(&i, doc))
+}
+
+type testLinter struct {
+	issues []result.Issue
+}
+
+func (l testLinter) Lint(_ context.Context, _ []string) ([]result.Issue, error) {
+	return l.issues, nil
+}
+
+type testClient struct {
+	t   *testing.T
+	in  io.Writer
+	out *conn
+	id  int
+}
+
+func (c *testClient) send(method string, params interface{}, isRequest bool) {
+	msg := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+	if isRequest {
+		c.id++
+		msg["id"] = c.id
+	}
+	body, err := json.Marshal(msg)
+	require.NoError(c.t, err)
+	_, err = fmt.Fprintf(c.in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	require.NoError(c.t, err)
+}
+
+func (c *testClient) receive(v interface{}) {
+	body, err := c.out.read()
+	require.NoError(c.t, err)
+	require.NoError(c.t, json.Unmarshal(body, v))
+}
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lsp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.go")
+	text := "package a\n\nvar x = 1\n"
+	require.NoError(t, ioutil.WriteFile(path, []byte(text), os.ModePerm))
+
+	linter := testLinter{issues: []result.Issue{{
+		FromLinter:  "gofmt",
+		Text:        "File is not gofmt-ed",
+		Pos:         token.Position{Filename: path, Line: 3, Column: 5},
+		Replacement: &result.Replacement{NewLines: []string{"var x = 2"}},
+	}}}
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	srv := NewServer(inR, outW, linter, logutils.NewStderrLog("test"))
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(context.Background())
+	}()
+
+	c := &testClient{t: t, in: inW, out: newConn(outR, nil)}
+	c.send("initialize", map[string]interface{}{}, true)
+	var initResp struct {
+		Result initializeResult
+	}
+	c.receive(&initResp)
+	assert.True(t, initResp.Result.Capabilities.CodeActionProvider)
+
+	uri := pathToURI(path)
+	c.send("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri, "languageId": "go", "version": 1, "text": text},
+	}, false)
+	var diagsNotification struct {
+		Method string
+		Params publishDiagnosticsParams
+	}
+	c.receive(&diagsNotification)
+	assert.Equal(t, "textDocument/publishDiagnostics", diagsNotification.Method)
+	assert.Equal(t, uri, diagsNotification.Params.URI)
+	assert.Equal(t, []Diagnostic{{
+		Range:    Range{Start: Position{Line: 2, Character: 4}, End: Position{Line: 2, Character: 9}},
+		Severity: severityWarning,
+		Source:   "gofmt",
+		Message:  "File is not gofmt-ed",
+	}}, diagsNotification.Params.Diagnostics)
+
+	c.send("textDocument/codeAction", map[string]interface{}{
+		"textDocument": map[string]interface{}{"uri": uri},
+		"range":        Range{Start: Position{Line: 2}, End: Position{Line: 2}},
+		"context":      map[string]interface{}{"diagnostics": []interface{}{}},
+	}, true)
+	var actionsResp struct {
+		Result []CodeAction
+	}
+	c.receive(&actionsResp)
+	require.Len(t, actionsResp.Result, 2)
+	assert.Equal(t, "var x = 2\n", actionsResp.Result[0].Edit.Changes[uri][0].NewText)
+	assert.Equal(t, " //nolint:gofmt", actionsResp.Result[1].Edit.Changes[uri][0].NewText)
+
+	c.send("shutdown", nil, true)
+	var shutdownResp map[string]interface{}
+	c.receive(&shutdownResp)
+	assert.Contains(t, shutdownResp, "result")
+
+	c.send("exit", nil, false)
+	assert.NoError(t, <-served)
+}
@@ -0,0 +1,403 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/daemon"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Linter analyzes packages for the server. Calls aren't concurrent.
+type Linter interface {
+	// Lint returns issues of packages in the directories (absolute paths).
+	Lint(ctx context.Context, dirs []string) ([]result.Issue, error)
+}
+
+// Server publishes diagnostics for Go files on open and save: the package
+// of the file is analyzed in the background and issues of all files of the
+// package are published. Code actions apply suggested fixes of issues or
+// suppress them by //nolint comments.
+type Server struct {
+	conn   *conn
+	linter Linter
+	log    logutils.Log
+	store  *daemon.IssuesStore
+
+	lintCh chan struct{}
+
+	mu          sync.Mutex
+	documents   map[string]document // opened files by path
+	published   map[string]bool     // files with non-empty published diagnostics
+	pendingDirs map[string]bool
+	shutdown    bool
+}
+
+func NewServer(in io.Reader, out io.Writer, linter Linter, log logutils.Log) *Server {
+	return &Server{
+		conn:        newConn(in, out),
+		linter:      linter,
+		log:         log,
+		store:       daemon.NewIssuesStore(),
+		lintCh:      make(chan struct{}, 1),
+		documents:   map[string]document{},
+		published:   map[string]bool{},
+		pendingDirs: map[string]bool{},
+	}
+}
+
+// Serve handles messages until the exit notification or the end of input.
+// It returns an error if the client exits without the shutdown request.
+func (s *Server) Serve(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go s.lintInBackground(ctx)
+
+	for {
+		body, err := s.conn.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to read message")
+		}
+
+		var req request
+		if err = json.Unmarshal(body, &req); err != nil {
+			s.replyError(nil, codeParseError, fmt.Sprintf("invalid message: %s", err))
+			continue
+		}
+
+		if req.Method == "exit" {
+			break
+		}
+		s.handle(&req)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.shutdown {
+		return errors.New("client exited without shutdown")
+	}
+	return nil
+}
+
+func (s *Server) handle(req *request) {
+	var res interface{}
+	var err error
+
+	switch req.Method {
+	case "initialize":
+		res = initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync: textDocumentSyncOptions{
+					OpenClose: true,
+					Change:    textDocumentSyncNone, // files are analyzed from disk, only saved text matters
+					Save:      saveOptions{IncludeText: true},
+				},
+				CodeActionProvider: true,
+			},
+			ServerInfo: serverInfo{Name: "golangci-lint"},
+		}
+	case "shutdown":
+		s.mu.Lock()
+		s.shutdown = true
+		s.mu.Unlock()
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			err = s.didOpen(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didSave":
+		var params didSaveParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			err = s.didSave(params.TextDocument.URI, params.Text)
+		}
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			err = s.didClose(params.TextDocument.URI)
+		}
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			res, err = s.codeActions(params.TextDocument.URI, params.Range)
+		}
+	default:
+		if req.ID != nil {
+			s.replyError(req.ID, codeMethodNotFound, fmt.Sprintf("method %q is not supported", req.Method))
+		}
+		return // unsupported notifications are ignored
+	}
+
+	if req.ID == nil {
+		if err != nil {
+			s.log.Warnf("Failed to handle %s: %s", req.Method, err)
+		}
+		return
+	}
+
+	if err != nil {
+		s.replyError(req.ID, codeInvalidParams, err.Error())
+		return
+	}
+	s.send(response{JSONRPC: "2.0", ID: req.ID, Result: res})
+}
+
+func (s *Server) send(msg interface{}) {
+	if err := s.conn.write(msg); err != nil {
+		s.log.Warnf("Failed to send message: %s", err)
+	}
+}
+
+func (s *Server) replyError(id *json.RawMessage, code int, message string) {
+	s.send(errorResponse{
+		JSONRPC: "2.0",
+		ID:      id,
+		Error:   responseError{Code: code, Message: message},
+	})
+}
+
+func (s *Server) notify(method string, params interface{}) {
+	s.send(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *Server) didOpen(uri, text string) error {
+	path, err := uriToPath(uri)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.documents[path] = newDocument(text)
+	s.mu.Unlock()
+
+	s.scheduleLint(path)
+	return nil
+}
+
+func (s *Server) didSave(uri string, text *string) error {
+	path, err := uriToPath(uri)
+	if err != nil {
+		return err
+	}
+
+	var doc document
+	if text != nil {
+		doc = newDocument(*text)
+	} else {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", path)
+		}
+		doc = newDocument(string(data))
+	}
+
+	s.mu.Lock()
+	s.documents[path] = doc
+	s.mu.Unlock()
+
+	s.scheduleLint(path)
+	return nil
+}
+
+func (s *Server) didClose(uri string) error {
+	path, err := uriToPath(uri)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.documents, path)
+	if s.published[path] {
+		delete(s.published, path)
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         uri,
+			Diagnostics: []Diagnostic{},
+		})
+	}
+	return nil
+}
+
+func (s *Server) scheduleLint(path string) {
+	if !strings.HasSuffix(path, ".go") {
+		return
+	}
+
+	s.mu.Lock()
+	s.pendingDirs[filepath.Dir(path)] = true
+	s.mu.Unlock()
+
+	select {
+	case s.lintCh <- struct{}{}:
+	default: // linting is already scheduled
+	}
+}
+
+func (s *Server) lintInBackground(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.lintCh:
+		}
+
+		s.mu.Lock()
+		var dirs []string
+		for dir := range s.pendingDirs {
+			dirs = append(dirs, dir)
+		}
+		s.pendingDirs = map[string]bool{}
+		s.mu.Unlock()
+
+		sort.Strings(dirs)
+		issues, err := s.linter.Lint(ctx, dirs)
+		if err != nil {
+			s.log.Warnf("Failed to lint %v: %s", dirs, err)
+			s.notify("window/showMessage", showMessageParams{
+				Type:    messageTypeError,
+				Message: fmt.Sprintf("golangci-lint failed: %s", err),
+			})
+			continue
+		}
+
+		s.store.Replace(dirs, issues)
+		s.publish(dirs)
+	}
+}
+
+// publish publishes diagnostics of files in the directories: files with
+// issues, opened files and files whose issues disappeared.
+func (s *Server) publish(dirs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	issuesByPath := map[string][]result.Issue{}
+	for _, i := range s.store.Issues(dirs) {
+		path, err := filepath.Abs(i.FilePath())
+		if err != nil {
+			continue
+		}
+		issuesByPath[path] = append(issuesByPath[path], i)
+	}
+
+	inDirs := map[string]bool{}
+	for _, dir := range dirs {
+		inDirs[dir] = true
+	}
+
+	paths := map[string]bool{}
+	for path := range issuesByPath {
+		paths[path] = true
+	}
+	for path := range s.documents {
+		if inDirs[filepath.Dir(path)] {
+			paths[path] = true
+		}
+	}
+	for path := range s.published {
+		if inDirs[filepath.Dir(path)] {
+			paths[path] = true
+		}
+	}
+
+	for path := range paths {
+		doc, err := s.getDocument(path)
+		if err != nil {
+			s.log.Warnf("Can't publish diagnostics: %s", err)
+			continue
+		}
+
+		diags := []Diagnostic{}
+		for _, i := range issuesByPath[path] {
+			i := i
+			diags = append(diags, issueToDiagnostic(&i, doc))
+		}
+
+		if len(diags) == 0 {
+			delete(s.published, path)
+		} else {
+			s.published[path] = true
+		}
+		s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         pathToURI(path),
+			Diagnostics: diags,
+		})
+	}
+}
+
+// getDocument returns the opened document or reads it from disk.
+// It must be called with the locked mutex.
+func (s *Server) getDocument(path string) (document, error) {
+	if doc, ok := s.documents[path]; ok {
+		return doc, nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return document{}, errors.Wrapf(err, "failed to read %s", path)
+	}
+	return newDocument(string(data)), nil
+}
+
+func (s *Server) codeActions(uri string, rng Range) ([]CodeAction, error) {
+	path, err := uriToPath(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	doc, err := s.getDocument(path)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := []CodeAction{}
+	nolintLinters := map[string]bool{}
+	for _, i := range s.store.Issues([]string{path}) {
+		i := i
+		diag := issueToDiagnostic(&i, doc)
+		if diag.Range.End.Line < rng.Start.Line || diag.Range.Start.Line > rng.End.Line {
+			continue
+		}
+
+		if edit := replacementEdit(&i, doc); edit != nil {
+			actions = append(actions, CodeAction{
+				Title:       fmt.Sprintf("Apply fix suggested by %s", i.FromLinter),
+				Kind:        codeActionQuickFix,
+				Diagnostics: []Diagnostic{diag},
+				Edit:        &WorkspaceEdit{Changes: map[string][]TextEdit{uri: {*edit}}},
+			})
+		}
+
+		// Only one directive per linter and line makes sense.
+		key := fmt.Sprintf("%s:%d", i.FromLinter, i.Line())
+		if nolintLinters[key] {
+			continue
+		}
+		nolintLinters[key] = true
+		actions = append(actions, CodeAction{
+			Title:       fmt.Sprintf("Suppress with //nolint:%s", i.FromLinter),
+			Kind:        codeActionQuickFix,
+			Diagnostics: []Diagnostic{diag},
+			Edit:        &WorkspaceEdit{Changes: map[string][]TextEdit{uri: {nolintEdit(&i, doc)}}},
+		})
+	}
+
+	return actions, nil
+}
@@ -30,13 +30,17 @@ type checkstyleError struct {
 
 const defaultSeverity = "error"
 
-type Checkstyle struct{}
+type Checkstyle struct {
+	printRuleID bool
+}
 
-func NewCheckstyle() *Checkstyle {
-	return &Checkstyle{}
+func NewCheckstyle(printRuleID bool) *Checkstyle {
+	return &Checkstyle{
+		printRuleID: printRuleID,
+	}
 }
 
-func (Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
+func (p Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
 	out := checkstyleOutput{
 		Version: "5.0",
 	}
@@ -57,7 +61,7 @@ func (Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
 			Column:   issue.Column(),
 			Line:     issue.Line(),
 			Message:  issue.Text,
-			Source:   issue.FromLinter,
+			Source:   linterName(&issue, p.printRuleID),
 			Severity: defaultSeverity,
 		}
 
@@ -14,6 +14,7 @@ import (
 // It is just enough to support GitLab CI Code Quality - https://docs.gitlab.com/ee/user/project/merge_requests/code_quality.html
 type CodeClimateIssue struct {
 	Description string `json:"description"`
+	CheckName   string `json:"check_name"`
 	Fingerprint string `json:"fingerprint"`
 	Location    struct {
 		Path  string `json:"path"`
@@ -35,6 +36,7 @@ func (p CodeClimate) Print(ctx context.Context, issues <-chan result.Issue) erro
 	for i := range issues {
 		var issue CodeClimateIssue
 		issue.Description = i.FromLinter + ": " + i.Text
+		issue.CheckName = i.QualifiedRuleID()
 		issue.Location.Path = i.Pos.Filename
 		issue.Location.Lines.Begin = i.Pos.Line
 
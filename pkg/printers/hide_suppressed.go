@@ -0,0 +1,36 @@
+package printers
+
+import (
+	"context"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// HideSuppressed hides issues suppressed with --show-suppressed from printers
+// having no way to mark an issue as suppressed.
+type HideSuppressed struct {
+	p Printer
+}
+
+func NewHideSuppressed(p Printer) *HideSuppressed {
+	return &HideSuppressed{p: p}
+}
+
+func (hs HideSuppressed) Print(ctx context.Context, issues <-chan result.Issue) error {
+	shownIssues := make(chan result.Issue, 1024)
+	go func() {
+		defer close(shownIssues)
+		for i := range issues {
+			if i.Suppression == nil {
+				shownIssues <- i
+			}
+		}
+	}()
+
+	err := hs.p.Print(ctx, shownIssues)
+
+	// Drain issues if the printer has stopped reading them.
+	for range shownIssues {
+	}
+	return err
+}
@@ -32,13 +32,16 @@ type failureXML struct {
 }
 
 type JunitXML struct {
+	printRuleID bool
 }
 
-func NewJunitXML() *JunitXML {
-	return &JunitXML{}
+func NewJunitXML(printRuleID bool) *JunitXML {
+	return &JunitXML{
+		printRuleID: printRuleID,
+	}
 }
 
-func (JunitXML) Print(ctx context.Context, issues <-chan result.Issue) error {
+func (p JunitXML) Print(ctx context.Context, issues <-chan result.Issue) error {
 	suites := make(map[string]testSuiteXML) // use a map to group by file
 
 	for i := range issues {
@@ -47,7 +50,7 @@ func (JunitXML) Print(ctx context.Context, issues <-chan result.Issue) error {
 		testSuite.Suite = i.FilePath()
 
 		tc := testCaseXML{
-			Name:      i.FromLinter,
+			Name:      linterName(&i, p.printRuleID),
 			ClassName: i.Pos.String(),
 			Failure: failureXML{
 				Message: i.Text,
@@ -2,6 +2,7 @@ package printers
 
 import (
 	"context"
+	"strings"
 
 	"github.com/golangci/golangci-lint/pkg/result"
 )
@@ -9,3 +10,23 @@ import (
 type Printer interface {
 	Print(ctx context.Context, issues <-chan result.Issue) error
 }
+
+// linterName returns the linter of the issue followed by its rule ID
+// if printRuleID is set, e.g. gosec/G104.
+func linterName(i *result.Issue, printRuleID bool) string {
+	if printRuleID {
+		return i.QualifiedRuleID()
+	}
+
+	return i.FromLinter
+}
+
+// issueText returns the text of the issue without the rule ID prefix
+// if the rule ID is printed after the linter name.
+func issueText(i *result.Issue, printRuleID bool) string {
+	if printRuleID && i.RuleID != "" {
+		return strings.TrimPrefix(i.Text, i.RuleID+": ")
+	}
+
+	return i.Text
+}
@@ -0,0 +1,163 @@
+package printers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+)
+
+// sarifOutput is a subset of the SARIF spec - https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifOutput struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	} `json:"driver"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID           string             `json:"ruleId"`
+	Level            string             `json:"level"`
+	Message          sarifMessage       `json:"message"`
+	Locations        []sarifLocation    `json:"locations"`
+	RelatedLocations []sarifLocation    `json:"relatedLocations,omitempty"`
+	Suppressions     []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               *int          `json:"id,omitempty"`
+	PhysicalLocation sarifPhysical `json:"physicalLocation"`
+	Message          *sarifMessage `json:"message,omitempty"`
+}
+
+type sarifPhysical struct {
+	ArtifactLocation struct {
+		URI string `json:"uri"`
+	} `json:"artifactLocation"`
+	Region *sarifRegion `json:"region,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+}
+
+type Sarif struct{}
+
+func NewSarif() *Sarif {
+	return &Sarif{}
+}
+
+func newSarifPhysical(pos token.Position, lineRange *result.Range) sarifPhysical {
+	var ret sarifPhysical
+	ret.ArtifactLocation.URI = filepath.ToSlash(pos.Filename)
+	if pos.Line != 0 {
+		ret.Region = &sarifRegion{
+			StartLine:   pos.Line,
+			StartColumn: pos.Column,
+		}
+		if lineRange != nil && lineRange.To > pos.Line {
+			ret.Region.EndLine = lineRange.To
+		}
+	}
+	return ret
+}
+
+func newSarifSuppression(s *result.Suppression) sarifSuppression {
+	kind := "external"
+	if s.Kind == "nolint" {
+		kind = "inSource"
+	}
+	return sarifSuppression{
+		Kind:          kind,
+		Justification: s.Source,
+	}
+}
+
+func (Sarif) Print(ctx context.Context, issues <-chan result.Issue) error {
+	run := sarifRun{
+		Results: []sarifResult{},
+	}
+	run.Tool.Driver.Name = "golangci-lint"
+	run.Tool.Driver.InformationURI = "https://github.com/golangci/golangci-lint"
+
+	ruleIDs := map[string]bool{}
+	for i := range issues {
+		ruleIDs[i.QualifiedRuleID()] = true
+
+		res := sarifResult{
+			RuleID:    i.QualifiedRuleID(),
+			Level:     defaultSeverity,
+			Message:   sarifMessage{Text: i.Text},
+			Locations: []sarifLocation{{PhysicalLocation: newSarifPhysical(i.Pos, i.LineRange)}},
+		}
+		for j, related := range i.Related {
+			id := j
+			res.RelatedLocations = append(res.RelatedLocations, sarifLocation{
+				ID:               &id,
+				PhysicalLocation: newSarifPhysical(related.Pos, nil),
+				Message:          &sarifMessage{Text: related.Message},
+			})
+		}
+		if i.Suppression != nil {
+			res.Suppressions = []sarifSuppression{newSarifSuppression(i.Suppression)}
+		}
+		run.Results = append(run.Results, res)
+	}
+
+	run.Tool.Driver.Rules = []sarifRule{}
+	for id := range ruleIDs {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	outputJSON, err := json.Marshal(sarifOutput{
+		Version: sarifVersion,
+		Schema:  sarifSchemaURI,
+		Runs:    []sarifRun{run},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(logutils.StdOut, string(outputJSON))
+	return nil
+}
@@ -14,12 +14,14 @@ import (
 
 type Tab struct {
 	printLinterName bool
+	printRuleID     bool
 	log             logutils.Log
 }
 
-func NewTab(printLinterName bool, log logutils.Log) *Tab {
+func NewTab(printLinterName, printRuleID bool, log logutils.Log) *Tab {
 	return &Tab{
 		printLinterName: printLinterName,
+		printRuleID:     printRuleID,
 		log:             log,
 	}
 }
@@ -45,9 +47,10 @@ func (p *Tab) Print(ctx context.Context, issues <-chan result.Issue) error {
 }
 
 func (p Tab) printIssue(i *result.Issue, w io.Writer) {
-	text := p.SprintfColored(color.FgRed, "%s", i.Text)
+	printRuleID := p.printLinterName && p.printRuleID
+	text := p.SprintfColored(color.FgRed, "%s", issueText(i, printRuleID))
 	if p.printLinterName {
-		text = fmt.Sprintf("%s\t%s", i.FromLinter, text)
+		text = fmt.Sprintf("%s\t%s", linterName(i, printRuleID), text)
 	}
 
 	pos := p.SprintfColored(color.Bold, "%s:%d", i.FilePath(), i.Line())
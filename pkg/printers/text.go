@@ -14,15 +14,17 @@ type Text struct {
 	printIssuedLine bool
 	useColors       bool
 	printLinterName bool
+	printRuleID     bool
 
 	log logutils.Log
 }
 
-func NewText(printIssuedLine, useColors, printLinterName bool, log logutils.Log) *Text {
+func NewText(printIssuedLine, useColors, printLinterName, printRuleID bool, log logutils.Log) *Text {
 	return &Text{
 		printIssuedLine: printIssuedLine,
 		useColors:       useColors,
 		printLinterName: printLinterName,
+		printRuleID:     printRuleID,
 		log:             log,
 	}
 }
@@ -41,21 +43,22 @@ func (p *Text) Print(ctx context.Context, issues <-chan result.Issue) error {
 		i := i
 		p.printIssue(&i)
 
-		if !p.printIssuedLine {
-			continue
+		if p.printIssuedLine {
+			p.printSourceCode(&i)
+			p.printUnderLinePointer(&i)
 		}
 
-		p.printSourceCode(&i)
-		p.printUnderLinePointer(&i)
+		p.printRelated(&i)
 	}
 
 	return nil
 }
 
 func (p Text) printIssue(i *result.Issue) {
-	text := p.SprintfColored(color.FgRed, "%s", i.Text)
+	printRuleID := p.printLinterName && p.printRuleID
+	text := p.SprintfColored(color.FgRed, "%s", issueText(i, printRuleID))
 	if p.printLinterName {
-		text += fmt.Sprintf(" (%s)", i.FromLinter)
+		text += fmt.Sprintf(" (%s)", linterName(i, printRuleID))
 	}
 	pos := p.SprintfColored(color.Bold, "%s:%d", i.FilePath(), i.Line())
 	if i.Pos.Column != 0 {
@@ -64,6 +67,16 @@ func (p Text) printIssue(i *result.Issue) {
 	fmt.Fprintf(logutils.StdOut, "%s: %s\n", pos, text)
 }
 
+func (p Text) printRelated(i *result.Issue) {
+	for _, r := range i.Related {
+		pos := p.SprintfColored(color.Bold, "%s:%d", r.Pos.Filename, r.Pos.Line)
+		if r.Pos.Column != 0 {
+			pos += fmt.Sprintf(":%d", r.Pos.Column)
+		}
+		fmt.Fprintf(logutils.StdOut, "\t%s: %s\n", pos, r.Message)
+	}
+}
+
 func (p Text) printSourceCode(i *result.Issue) {
 	for _, line := range i.SourceLines {
 		fmt.Fprintln(logutils.StdOut, line)
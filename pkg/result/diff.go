@@ -0,0 +1,78 @@
+package result
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+// Fingerprint identifies an issue between runs: it doesn't depend on the line
+// of the issue because lines above it can be edited, but it depends on
+// the source lines of the issue.
+func (i *Issue) Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00", i.FromLinter, i.FilePath(), i.Text)
+	for _, line := range i.SourceLines {
+		fmt.Fprintf(h, "%s\n", strings.TrimSpace(line))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)[:8])
+}
+
+// fuzzyKey matches issues which source lines changed but which are likely
+// the same: e.g. an issue in a function which got a new argument.
+func (i *Issue) fuzzyKey() string {
+	return fmt.Sprintf("%s|%s|%s", i.FromLinter, i.FilePath(), i.Text)
+}
+
+// IssuesDiff is a difference between issues of two runs.
+type IssuesDiff struct {
+	New       []Issue
+	Fixed     []Issue
+	Unchanged []Issue // issues of the new run
+}
+
+// DiffIssues matches issues of two runs by fingerprints and then remaining
+// issues by linter, path and text. Not matched issues are new or fixed.
+func DiffIssues(oldIssues, newIssues []Issue) *IssuesDiff {
+	oldMatched := make([]bool, len(oldIssues))
+	newMatched := make([]bool, len(newIssues))
+
+	for _, key := range []func(i *Issue) string{(*Issue).Fingerprint, (*Issue).fuzzyKey} {
+		oldByKey := map[string][]int{}
+		for i := range oldIssues {
+			if !oldMatched[i] {
+				k := key(&oldIssues[i])
+				oldByKey[k] = append(oldByKey[k], i)
+			}
+		}
+
+		for i := range newIssues {
+			if newMatched[i] {
+				continue
+			}
+
+			k := key(&newIssues[i])
+			if candidates := oldByKey[k]; len(candidates) != 0 {
+				oldMatched[candidates[0]] = true
+				newMatched[i] = true
+				oldByKey[k] = candidates[1:]
+			}
+		}
+	}
+
+	ret := &IssuesDiff{}
+	for i := range newIssues {
+		if newMatched[i] {
+			ret.Unchanged = append(ret.Unchanged, newIssues[i])
+		} else {
+			ret.New = append(ret.New, newIssues[i])
+		}
+	}
+	for i := range oldIssues {
+		if !oldMatched[i] {
+			ret.Fixed = append(ret.Fixed, oldIssues[i])
+		}
+	}
+
+	return ret
+}
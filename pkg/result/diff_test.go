@@ -0,0 +1,45 @@
+package result
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIssue(linter, file string, line int, text, source string) Issue {
+	return Issue{
+		FromLinter:  linter,
+		Text:        text,
+		Pos:         token.Position{Filename: file, Line: line},
+		SourceLines: []string{source},
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	i := newTestIssue("govet", "a.go", 10, "bad", "\tx := 1")
+	moved := newTestIssue("govet", "a.go", 20, "bad", "  x := 1")
+	changed := newTestIssue("govet", "a.go", 10, "bad", "\tx := 2")
+
+	assert.Equal(t, i.Fingerprint(), moved.Fingerprint())
+	assert.NotEqual(t, i.Fingerprint(), changed.Fingerprint())
+}
+
+func TestDiffIssues(t *testing.T) {
+	oldIssues := []Issue{
+		newTestIssue("govet", "a.go", 10, "bad", "x := 1"),
+		newTestIssue("govet", "a.go", 15, "bad", "y := 1"),
+		newTestIssue("golint", "b.go", 1, "fixed", "z := 1"),
+	}
+	newIssues := []Issue{
+		newTestIssue("govet", "a.go", 17, "bad", "y := 1"),   // moved
+		newTestIssue("govet", "a.go", 12, "bad", "x := 10"),  // changed source
+		newTestIssue("govet", "a.go", 30, "bad", "w := 1"),   // new: all similar old issues are matched
+		newTestIssue("golint", "c.go", 1, "fixed", "z := 1"), // new: another file
+	}
+
+	diff := DiffIssues(oldIssues, newIssues)
+	assert.Equal(t, []Issue{newIssues[0], newIssues[1]}, diff.Unchanged)
+	assert.Equal(t, []Issue{newIssues[2], newIssues[3]}, diff.New)
+	assert.Equal(t, []Issue{oldIssues[2]}, diff.Fixed)
+}
@@ -18,13 +18,36 @@ type InlineFix struct {
 	NewString string
 }
 
+// Suppression tells why an issue is suppressed: suppressed issues are kept
+// in results only with --show-suppressed.
+type Suppression struct {
+	Kind   string // e.g. nolint or exclude-rule
+	Source string // e.g. a nolint comment or an exclude rule
+}
+
+// RelatedLocation is another place of code an issue is about, e.g. a duplicate of code.
+type RelatedLocation struct {
+	Pos     token.Position
+	Message string
+}
+
 type Issue struct {
 	FromLinter string
 	Text       string
 	Pos        token.Position
 
+	// RuleID is a check of the linter reported the issue, e.g. an analyzer name
+	// or a check code like SA1019. It's empty for linters without sub-checks.
+	RuleID string `json:",omitempty"`
+
 	LineRange *Range `json:",omitempty"`
 
+	// Related are other places of code the issue is about
+	Related []RelatedLocation `json:",omitempty"`
+
+	// Suppression is set for suppressed issues
+	Suppression *Suppression `json:",omitempty"`
+
 	// HunkPos is used only when golangci-lint is run over a diff
 	HunkPos int `json:",omitempty"`
 
@@ -39,6 +62,14 @@ func (i *Issue) FilePath() string {
 	return i.Pos.Filename
 }
 
+// QualifiedRuleID returns linter/rule or only the linter if there is no rule ID.
+func (i *Issue) QualifiedRuleID() string {
+	if i.RuleID == "" {
+		return i.FromLinter
+	}
+	return i.FromLinter + "/" + i.RuleID
+}
+
 func (i *Issue) Line() int {
 	return i.Pos.Line
 }
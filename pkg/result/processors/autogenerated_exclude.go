@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/token"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
@@ -15,7 +16,9 @@ import (
 var autogenDebugf = logutils.Debug("autogen_exclude")
 
 type ageFileSummary struct {
-	isGenerated bool
+	isGenerated     bool
+	generatedReason string
+	hasRule         bool // a generated-by exclude rule covers the file
 }
 
 type ageFileSummaryCache map[string]*ageFileSummary
@@ -23,13 +26,23 @@ type ageFileSummaryCache map[string]*ageFileSummary
 type AutogeneratedExclude struct {
 	fileSummaryCache ageFileSummaryCache
 	astCache         *astcache.Cache
+	detector         *GeneratedDetector
+	generatedBy      []*regexp.Regexp
 }
 
-func NewAutogeneratedExclude(astCache *astcache.Cache) *AutogeneratedExclude {
-	return &AutogeneratedExclude{
+// NewAutogeneratedExclude expects generated-by regexps of exclude rules:
+// issues in generated files matching them are left to the exclude rules.
+func NewAutogeneratedExclude(astCache *astcache.Cache, detector *GeneratedDetector,
+	generatedBy []string) *AutogeneratedExclude {
+	p := &AutogeneratedExclude{
 		fileSummaryCache: ageFileSummaryCache{},
 		astCache:         astCache,
+		detector:         detector,
 	}
+	for _, re := range generatedBy {
+		p.generatedBy = append(p.generatedBy, regexp.MustCompile("(?i)"+re))
+	}
+	return p
 }
 
 var _ Processor = &AutogeneratedExclude{}
@@ -39,6 +52,10 @@ func (p AutogeneratedExclude) Name() string {
 }
 
 func (p *AutogeneratedExclude) Process(issues []result.Issue) ([]result.Issue, error) {
+	if p.detector.IsDisabled() {
+		return issues, nil
+	}
+
 	return filterIssuesErr(issues, p.shouldPassIssue)
 }
 
@@ -58,19 +75,30 @@ func (p *AutogeneratedExclude) shouldPassIssue(i *result.Issue) (bool, error) {
 		return false, nil
 	}
 
+	if filepath.Ext(i.FilePath()) != ".go" {
+		// e.g. an issue about an expired exclude rule in the config file
+		return true, nil
+	}
+
 	fs, err := p.getOrCreateFileSummary(i)
 	if err != nil {
 		return false, err
 	}
 
 	// don't report issues for autogenerated files
-	return !fs.isGenerated, nil
+	return !fs.isGenerated || fs.hasRule, nil
 }
 
 // isGenerated reports whether the source file is generated code.
 // Using a bit laxer rules than https://golang.org/s/generatedcode to
 // match more generated code. See #48 and #72.
 func isGeneratedFileByComment(doc string) bool {
+	return getGeneratedMarker(doc) != ""
+}
+
+// getGeneratedMarker returns a marker of generated code found in the doc or
+// an empty string.
+func getGeneratedMarker(doc string) string {
 	const (
 		genCodeGenerated = "code generated"
 		genDoNotEdit     = "do not edit"
@@ -82,12 +110,22 @@ func isGeneratedFileByComment(doc string) bool {
 	for _, marker := range markers {
 		if strings.Contains(doc, marker) {
 			autogenDebugf("doc contains marker %q: file is generated", marker)
-			return true
+			return marker
 		}
 	}
 
 	autogenDebugf("doc of len %d doesn't contain any of markers: %s", len(doc), markers)
-	return false
+	return ""
+}
+
+func (p *AutogeneratedExclude) Explain(i *result.Issue) string {
+	if isSpecialAutogeneratedFile(i.FilePath()) {
+		return "file is a fake file of goyacc generated code"
+	}
+	if fs := p.fileSummaryCache[i.FilePath()]; fs != nil && fs.isGenerated && !fs.hasRule {
+		return fmt.Sprintf("file is generated: %s", fs.generatedReason)
+	}
+	return ""
 }
 
 func (p *AutogeneratedExclude) getOrCreateFileSummary(i *result.Issue) (*ageFileSummary, error) {
@@ -110,10 +148,19 @@ func (p *AutogeneratedExclude) getOrCreateFileSummary(i *result.Issue) (*ageFile
 
 	autogenDebugf("file %q: astcache file is %+v", i.FilePath(), *f)
 
-	doc := getDoc(f.F, f.Fset, i.FilePath())
-
-	fs.isGenerated = isGeneratedFileByComment(doc)
+	fs.generatedReason = p.detector.Detect(i.FilePath(), f.F, f.Fset)
+	fs.isGenerated = fs.generatedReason != ""
 	autogenDebugf("file %q is generated: %t", i.FilePath(), fs.isGenerated)
+	if fs.isGenerated && len(p.generatedBy) != 0 {
+		doc := getDoc(f.F, f.Fset, i.FilePath())
+		for _, re := range p.generatedBy {
+			if re.MatchString(doc) {
+				autogenDebugf("file %q: generated-by %q of an exclude rule covers it", i.FilePath(), re)
+				fs.hasRule = true
+				break
+			}
+		}
+	}
 	return fs, nil
 }
 
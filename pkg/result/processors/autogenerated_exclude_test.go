@@ -1,10 +1,18 @@
 package processors
 
 import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 func TestIsAutogeneratedDetection(t *testing.T) {
@@ -66,3 +74,70 @@ func TestIsAutogeneratedDetection(t *testing.T) {
 		assert.False(t, isGenerated)
 	}
 }
+
+func TestGeneratedDetector(t *testing.T) {
+	const (
+		strictHeader = "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage p\n"
+		laxHeader    = "// This file was generated by a script, do not edit\n\npackage p\n"
+		customHeader = "// @generated by the build\n\npackage p\n"
+		notGenerated = "// Package p does things.\npackage p\n"
+	)
+
+	testCases := []struct {
+		name     string
+		cfg      config.Generated
+		fileName string
+		src      string
+		reason   string
+	}{
+		{"lax by default", config.Generated{}, "a.go", laxHeader,
+			`its header comments contain "do not edit"`},
+		{"lax strict header", config.Generated{Mode: config.GeneratedModeLax}, "a.go", strictHeader,
+			`its header comments contain "code generated"`},
+		{"not generated", config.Generated{}, "a.go", notGenerated, ""},
+		{"strict", config.Generated{Mode: config.GeneratedModeStrict}, "a.go", strictHeader,
+			`it has the comment "// Code generated by protoc-gen-go. DO NOT EDIT."`},
+		{"strict ignores lax header", config.Generated{Mode: config.GeneratedModeStrict}, "a.go", laxHeader, ""},
+		{"disabled", config.Generated{Mode: config.GeneratedModeDisabled, Files: []string{"*.go"}}, "a.go", strictHeader, ""},
+		{"file glob", config.Generated{Mode: config.GeneratedModeStrict, Files: []string{"*_mock.go"}}, "db_mock.go", notGenerated,
+			`its name matches "*_mock.go"`},
+		{"file glob with many dots", config.Generated{Files: []string{"*.pb.*.go"}}, "api.pb.gw.go", notGenerated,
+			`its name matches "*.pb.*.go"`},
+		{"file glob doesn't match", config.Generated{Files: []string{"*.pb.*.go"}}, "api.pb.go", notGenerated, ""},
+		{"custom header", config.Generated{Mode: config.GeneratedModeStrict, Headers: []string{`@generated`}}, "a.go", customHeader,
+			`its header comments match "@generated"`},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, tc.fileName, tc.src, parser.ParseComments)
+			assert.NoError(t, err)
+
+			d := NewGeneratedDetector(&tc.cfg)
+			assert.Equal(t, tc.reason, d.Detect(filepath.Join("dir", tc.fileName), f, fset))
+		})
+	}
+}
+
+func TestAutogeneratedExcludeGeneratedBy(t *testing.T) {
+	generatedPath := filepath.Join("testdata", "exclude_rules_generated.go")
+	funcsPath := filepath.Join("testdata", "exclude_rules_funcs.go")
+	astCache := astcache.LoadFromFilenames(logutils.NewStderrLog(""), generatedPath, funcsPath)
+	newIssue := func(path string) result.Issue {
+		return result.Issue{
+			Pos:        token.Position{Filename: path, Line: 5},
+			FromLinter: "unused",
+		}
+	}
+	issues := []result.Issue{newIssue(generatedPath), newIssue(funcsPath)}
+
+	detector := NewGeneratedDetector(&config.Generated{Mode: config.GeneratedModeLax})
+	p := NewAutogeneratedExclude(astCache, detector, []string{"other-generator"})
+	processAssertSame(t, p, issues[1])
+
+	// the rule decides which issues in the file are excluded
+	p = NewAutogeneratedExclude(astCache, detector, []string{"other-generator", "PROTOC-GEN-GO"})
+	processAssertSame(t, p, issues...)
+}
@@ -1,6 +1,7 @@
 package processors
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -55,4 +56,11 @@ func (p Cgo) Process(issues []result.Issue) ([]result.Issue, error) {
 	})
 }
 
+func (p Cgo) Explain(i *result.Issue) string {
+	if filepath.Base(i.FilePath()) == "_cgo_gotypes.go" {
+		return "file is generated by cgo"
+	}
+	return fmt.Sprintf("file is in the go build cache %s: it's preprocessed by cgo", p.goCacheDir)
+}
+
 func (Cgo) Finish() {}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/golangci/revgrep"
 
+	"github.com/golangci/golangci-lint/pkg/gitutil"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -18,16 +19,18 @@ type Diff struct {
 	fromRev       string
 	patchFilePath string
 	patch         string
+	staged        bool // compare the index with HEAD
 }
 
 var _ Processor = Diff{}
 
-func NewDiff(onlyNew bool, fromRev, patchFilePath string) *Diff {
+func NewDiff(onlyNew bool, fromRev, patchFilePath string, staged bool) *Diff {
 	return &Diff{
 		onlyNew:       onlyNew,
 		fromRev:       fromRev,
 		patchFilePath: patchFilePath,
 		patch:         os.Getenv("GOLANGCI_DIFF_PROCESSOR_PATCH"),
+		staged:        staged,
 	}
 }
 
@@ -36,7 +39,7 @@ func (p Diff) Name() string {
 }
 
 func (p Diff) Process(issues []result.Issue) ([]result.Issue, error) {
-	if !p.onlyNew && p.fromRev == "" && p.patchFilePath == "" && p.patch == "" { // no need to work
+	if !p.onlyNew && p.fromRev == "" && p.patchFilePath == "" && p.patch == "" && !p.staged { // no need to work
 		return issues, nil
 	}
 
@@ -49,6 +52,12 @@ func (p Diff) Process(issues []result.Issue) ([]result.Issue, error) {
 		patchReader = bytes.NewReader(patch)
 	} else if p.patch != "" {
 		patchReader = strings.NewReader(p.patch)
+	} else if p.staged {
+		patch, err := gitutil.StagedPatch()
+		if err != nil {
+			return nil, fmt.Errorf("can't get staged changes: %s", err)
+		}
+		patchReader = bytes.NewReader(patch)
 	}
 
 	c := revgrep.Checker{
@@ -71,4 +80,8 @@ func (p Diff) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
+func (Diff) Explain(i *result.Issue) string {
+	return "line isn't changed in the diff"
+}
+
 func (Diff) Finish() {}
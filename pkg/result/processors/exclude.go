@@ -1,13 +1,20 @@
 package processors
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 type Exclude struct {
-	pattern *regexp.Regexp
+	pattern  *regexp.Regexp
+	patterns []*regexp.Regexp // parts of the pattern to explain which of them matched
+
+	unused          *UnusedExcludes
+	checkedPatterns int   // first patterns are configured by user, others are default ones
+	matchCounts     []int // by patterns
 }
 
 var _ Processor = Exclude{}
@@ -22,6 +29,24 @@ func NewExclude(pattern string) *Exclude {
 	}
 }
 
+// NewExcludePatterns excludes issues matching any of the patterns or default patterns,
+// only patterns are reported by unused.
+func NewExcludePatterns(patterns, defaultPatterns []string, unused *UnusedExcludes) *Exclude {
+	allPatterns := append(append([]string{}, patterns...), defaultPatterns...)
+	if len(allPatterns) == 0 {
+		return NewExclude("")
+	}
+
+	p := NewExclude(fmt.Sprintf("(%s)", strings.Join(allPatterns, "|")))
+	for _, pattern := range allPatterns {
+		p.patterns = append(p.patterns, regexp.MustCompile("(?i)"+pattern))
+	}
+	p.unused = unused
+	p.checkedPatterns = len(patterns)
+	p.matchCounts = make([]int, len(allPatterns))
+	return p
+}
+
 func (p Exclude) Name() string {
 	return "exclude"
 }
@@ -32,8 +57,45 @@ func (p Exclude) Process(issues []result.Issue) ([]result.Issue, error) {
 	}
 
 	return filterIssues(issues, func(i *result.Issue) bool {
-		return !p.pattern.MatchString(i.Text)
+		if !p.pattern.MatchString(i.Text) {
+			return true
+		}
+
+		if p.unused != nil {
+			if idx := p.matchedPattern(i); idx != -1 {
+				p.matchCounts[idx]++
+			}
+		}
+		return false
 	}), nil
 }
 
-func (p Exclude) Finish() {}
+func (p Exclude) matchedPattern(i *result.Issue) int {
+	for idx, pattern := range p.patterns {
+		if pattern.MatchString(i.Text) {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (p Exclude) Explain(i *result.Issue) string {
+	if idx := p.matchedPattern(i); idx != -1 {
+		return fmt.Sprintf("text matches exclude pattern %q", strings.TrimPrefix(p.patterns[idx].String(), "(?i)"))
+	}
+	return fmt.Sprintf("text matches exclude pattern %q", strings.TrimPrefix(p.pattern.String(), "(?i)"))
+}
+
+func (p Exclude) Finish() {
+	if p.unused == nil {
+		return
+	}
+
+	var unused []string
+	for idx := 0; idx < p.checkedPatterns; idx++ {
+		if p.matchCounts[idx] == 0 {
+			unused = append(unused, fmt.Sprintf("Exclude pattern %q", strings.TrimPrefix(p.patterns[idx].String(), "(?i)")))
+		}
+	}
+	p.unused.report("exclude patterns", unused)
+}
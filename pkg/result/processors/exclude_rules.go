@@ -1,8 +1,13 @@
 package processors
 
 import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
 	"regexp"
+	"time"
 
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 
 	"github.com/golangci/golangci-lint/pkg/fsutils"
@@ -11,38 +16,80 @@ import (
 )
 
 type excludeRule struct {
-	text    *regexp.Regexp
-	source  *regexp.Regexp
-	path    *regexp.Regexp
-	linters []string
+	text        *regexp.Regexp
+	source      *regexp.Regexp
+	path        *regexp.Regexp
+	ruleID      *regexp.Regexp
+	pkg         *regexp.Regexp
+	function    *regexp.Regexp
+	generatedBy *regexp.Regexp
+	linters     []string
+	expired     bool // expired rules are reported as issues
 }
 
 func (r *excludeRule) isEmpty() bool {
-	return r.text == nil && r.path == nil && len(r.linters) == 0
+	return r.text == nil && r.path == nil && r.ruleID == nil && len(r.linters) == 0 &&
+		r.pkg == nil && r.function == nil && r.generatedBy == nil
 }
 
 type ExcludeRule struct {
-	Text    string
-	Source  string
-	Path    string
-	Linters []string
+	Text        string
+	Source      string
+	Path        string
+	RuleID      string
+	Package     string
+	Function    string
+	GeneratedBy string
+	Linters     []string
+	SuppressionExpiry
+}
+
+// funcRange is lines of a function declaration.
+type funcRange struct {
+	result.Range
+	name string // Func or Type.Method
+}
+
+// excludeRulesFile is info about a file for package, function and generated-by matchers.
+type excludeRulesFile struct {
+	pkgPath     string
+	funcs       []funcRange
+	isGenerated bool
+	doc         string // header comments
 }
 
 type ExcludeRules struct {
 	rules     []excludeRule
 	lineCache *fsutils.LineCache
+	astCache  *astcache.Cache
+	generated *GeneratedDetector
 	log       logutils.Log
+
+	files map[string]*excludeRulesFile
+
+	unused      *UnusedExcludes
+	matchCounts []int // by rules
 }
 
-func NewExcludeRules(rules []ExcludeRule, lineCache *fsutils.LineCache, log logutils.Log) *ExcludeRules {
+// NewExcludeRules expects a detector of generated files for generated-by
+// matchers: the autogenerated exclude processor leaves issues in files
+// matching them to the rules.
+func NewExcludeRules(rules []ExcludeRule, lineCache *fsutils.LineCache, astCache *astcache.Cache,
+	generated *GeneratedDetector, log logutils.Log, unused *UnusedExcludes) *ExcludeRules {
 	r := &ExcludeRules{
-		lineCache: lineCache,
-		log:       log,
+		lineCache:   lineCache,
+		astCache:    astCache,
+		generated:   generated,
+		log:         log,
+		files:       map[string]*excludeRulesFile{},
+		unused:      unused,
+		matchCounts: make([]int, len(rules)),
 	}
 
 	for _, rule := range rules {
 		parsedRule := excludeRule{
 			linters: rule.Linters,
+			expired: rule.IsExpired(time.Now()),
 		}
 		if rule.Text != "" {
 			parsedRule.text = regexp.MustCompile("(?i)" + rule.Text)
@@ -53,6 +100,19 @@ func NewExcludeRules(rules []ExcludeRule, lineCache *fsutils.LineCache, log logu
 		if rule.Path != "" {
 			parsedRule.path = regexp.MustCompile(rule.Path)
 		}
+		if rule.RuleID != "" {
+			// match the whole rule ID: G10 shouldn't match G104
+			parsedRule.ruleID = regexp.MustCompile("^(?:" + rule.RuleID + ")$")
+		}
+		if rule.Package != "" {
+			parsedRule.pkg = regexp.MustCompile(rule.Package)
+		}
+		if rule.Function != "" {
+			parsedRule.function = regexp.MustCompile(rule.Function)
+		}
+		if rule.GeneratedBy != "" {
+			parsedRule.generatedBy = regexp.MustCompile("(?i)" + rule.GeneratedBy)
+		}
 		r.rules = append(r.rules, parsedRule)
 	}
 
@@ -64,16 +124,27 @@ func (p ExcludeRules) Process(issues []result.Issue) ([]result.Issue, error) {
 		return issues, nil
 	}
 	return filterIssues(issues, func(i *result.Issue) bool {
-		for _, rule := range p.rules {
-			rule := rule
-			if p.match(i, &rule) {
-				return false
-			}
+		idx := p.matchedRule(i)
+		if idx == -1 {
+			return true
 		}
-		return true
+
+		p.matchCounts[idx]++
+		return false
 	}), nil
 }
 
+// matchedRule returns an index of the first rule matching the issue or -1.
+func (p ExcludeRules) matchedRule(i *result.Issue) int {
+	for idx, rule := range p.rules {
+		rule := rule
+		if p.match(i, &rule) {
+			return idx
+		}
+	}
+	return -1
+}
+
 func (p ExcludeRules) matchLinter(i *result.Issue, r *excludeRule) bool {
 	for _, linter := range r.linters {
 		if linter == i.FromLinter {
@@ -94,8 +165,87 @@ func (p ExcludeRules) matchSource(i *result.Issue, r *excludeRule) bool { //noli
 	return r.source.MatchString(sourceLine)
 }
 
+func (p ExcludeRules) getFile(i *result.Issue) *excludeRulesFile {
+	if ef := p.files[i.FilePath()]; ef != nil {
+		return ef
+	}
+
+	ef := &excludeRulesFile{}
+	p.files[i.FilePath()] = ef
+	if filepath.Ext(i.FilePath()) != ".go" {
+		return ef // e.g. an issue about an expired exclude rule in the config file
+	}
+
+	f := p.astCache.Get(i.FilePath())
+	if f == nil || f.Err != nil {
+		p.log.Warnf("Can't get AST of %s for exclude rules: file isn't parsed", i.FilePath())
+		return ef
+	}
+
+	ef.pkgPath = f.PkgPath
+	for _, decl := range f.F.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		from := fd.Pos()
+		if fd.Doc != nil { // issues in the doc comment are about the function too
+			from = fd.Doc.Pos()
+		}
+		ef.funcs = append(ef.funcs, funcRange{
+			Range: result.Range{
+				From: f.Fset.Position(from).Line,
+				To:   f.Fset.Position(fd.End()).Line,
+			},
+			name: getFuncName(fd),
+		})
+	}
+	if p.generated != nil && p.generated.Detect(i.FilePath(), f.F, f.Fset) != "" {
+		ef.isGenerated = true
+		ef.doc = getDoc(f.F, f.Fset, i.FilePath())
+	}
+	return ef
+}
+
+// getFuncName returns Func for functions and Type.Method for methods.
+func getFuncName(fd *ast.FuncDecl) string {
+	if fd.Recv == nil || len(fd.Recv.List) == 0 {
+		return fd.Name.Name
+	}
+
+	recvType := fd.Recv.List[0].Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
+	}
+	if ident, ok := recvType.(*ast.Ident); ok {
+		return ident.Name + "." + fd.Name.Name
+	}
+	return fd.Name.Name
+}
+
+func (p ExcludeRules) matchFile(i *result.Issue, r *excludeRule) bool {
+	ef := p.getFile(i)
+	if r.pkg != nil && !r.pkg.MatchString(ef.pkgPath) {
+		return false
+	}
+	if r.generatedBy != nil && (!ef.isGenerated || !r.generatedBy.MatchString(ef.doc)) {
+		return false
+	}
+	if r.function == nil {
+		return true
+	}
+
+	for _, fr := range ef.funcs {
+		if i.Line() >= fr.From && i.Line() <= fr.To {
+			return r.function.MatchString(fr.name)
+		}
+	}
+	return false // the issue isn't in a function
+}
+
 func (p ExcludeRules) match(i *result.Issue, r *excludeRule) bool {
-	if r.isEmpty() {
+	if r.isEmpty() || r.expired {
 		return false
 	}
 	if r.text != nil && !r.text.MatchString(i.Text) {
@@ -104,10 +254,17 @@ func (p ExcludeRules) match(i *result.Issue, r *excludeRule) bool {
 	if r.path != nil && !r.path.MatchString(i.FilePath()) {
 		return false
 	}
+	if r.ruleID != nil && !r.ruleID.MatchString(i.RuleID) {
+		return false
+	}
 	if len(r.linters) != 0 && !p.matchLinter(i, r) {
 		return false
 	}
 
+	if (r.pkg != nil || r.function != nil || r.generatedBy != nil) && !p.matchFile(i, r) {
+		return false
+	}
+
 	// the most heavyweight checking last
 	if r.source != nil && !p.matchSource(i, r) {
 		return false
@@ -116,7 +273,28 @@ func (p ExcludeRules) match(i *result.Issue, r *excludeRule) bool {
 	return true
 }
 
+func (p ExcludeRules) Explain(i *result.Issue) string {
+	if idx := p.matchedRule(i); idx != -1 {
+		return fmt.Sprintf("issue matches exclude rule issues.exclude-rules[%d]", idx)
+	}
+	return ""
+}
+
 func (ExcludeRules) Name() string { return "exclude-rules" }
-func (ExcludeRules) Finish()      {}
+
+func (p ExcludeRules) Finish() {
+	if p.unused == nil {
+		return
+	}
+
+	var unused []string
+	for idx, count := range p.matchCounts {
+		rule := &p.rules[idx]
+		if count == 0 && !rule.expired && p.unused.isAnyLinterEnabled(rule.linters) {
+			unused = append(unused, fmt.Sprintf("Exclude rule issues.exclude-rules[%d]", idx))
+		}
+	}
+	p.unused.report("exclude rules", unused)
+}
 
 var _ Processor = ExcludeRules{}
@@ -9,6 +9,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -31,7 +34,7 @@ func TestExcludeRulesMultiple(t *testing.T) {
 			Source:  "^//go:generate ",
 			Linters: []string{"lll"},
 		},
-	}, lineCache, nil)
+	}, lineCache, nil, nil, nil, nil)
 	type issueCase struct {
 		Path   string
 		Line   int
@@ -85,7 +88,7 @@ func TestExcludeRulesText(t *testing.T) {
 				"linter",
 			},
 		},
-	}, nil, nil)
+	}, nil, nil, nil, nil, nil)
 	texts := []string{"excLude", "1", "", "exclud", "notexclude"}
 	var issues []result.Issue
 	for _, t := range texts {
@@ -104,6 +107,121 @@ func TestExcludeRulesText(t *testing.T) {
 	}
 	assert.Equal(t, texts[1:], processedTexts)
 }
+
+func TestExcludeRulesRuleID(t *testing.T) {
+	p := NewExcludeRules([]ExcludeRule{
+		{
+			RuleID:  "G10",
+			Linters: []string{"gosec"},
+		},
+		{
+			RuleID:  "SA1019|SA4006",
+			Linters: []string{"staticcheck"},
+		},
+	}, nil, nil, nil, nil, nil)
+	issues := []result.Issue{
+		{FromLinter: "gosec", RuleID: "G10", Text: "excluded"},
+		{FromLinter: "gosec", RuleID: "G104", Text: "rule ID must fully match"},
+		{FromLinter: "staticcheck", RuleID: "SA4006", Text: "excluded"},
+		{FromLinter: "staticcheck", Text: "no rule ID"},
+	}
+
+	processedIssues := process(t, p, issues...)
+	assert.Equal(t, []result.Issue{issues[1], issues[3]}, processedIssues)
+}
+
 func TestExcludeRulesEmpty(t *testing.T) {
-	processAssertSame(t, NewExcludeRules(nil, nil, nil), newTextIssue("test"))
+	processAssertSame(t, NewExcludeRules(nil, nil, nil, nil, nil, nil), newTextIssue("test"))
+}
+
+func TestExcludeRulesUnused(t *testing.T) {
+	log := logutils.NewMockLog()
+	log.On("Warnf", "%s didn't exclude any issue, remove it from the config", "Exclude rule issues.exclude-rules[1]")
+	log.On("Errorf", "Found %d unused %s in the config", 1, "exclude rules")
+
+	unused := &UnusedExcludes{Log: log, Fail: true}
+	unused.SetEnabledLinters([]string{"linter"})
+	p := NewExcludeRules([]ExcludeRule{
+		{
+			Text:    "^exclude$",
+			Linters: []string{"linter"},
+		},
+		{
+			Text:    "^fixed$",
+			Linters: []string{"linter"},
+		},
+		{
+			Text:    "^fixed$",
+			Linters: []string{"disabled"},
+		},
+	}, nil, nil, nil, nil, unused)
+
+	issue := newTextIssue("exclude")
+	issue.FromLinter = "linter"
+	processAssertEmpty(t, p, issue)
+
+	p.Finish()
+	log.AssertExpectations(t)
+}
+
+func TestExcludeRulesPackageFunctionGeneratedBy(t *testing.T) {
+	funcsPath := filepath.Join("testdata", "exclude_rules_funcs.go")
+	generatedPath := filepath.Join("testdata", "exclude_rules_generated.go")
+	mockPath := filepath.Join("testdata", "exclude_rules_mock.go")
+	astCache := astcache.LoadFromFilenames(logutils.NewStderrLog(""), funcsPath, generatedPath, mockPath)
+	astCache.Get(funcsPath).PkgPath = "github.com/org/repo/legacy/sub"
+	astCache.Get(generatedPath).PkgPath = "github.com/org/repo/api"
+	astCache.Get(mockPath).PkgPath = "github.com/org/repo/legacy_test" // an external test package
+	generated := NewGeneratedDetector(&config.Generated{
+		Mode:  config.GeneratedModeLax,
+		Files: []string{"*_mock.go"},
+	})
+
+	p := NewExcludeRules([]ExcludeRule{
+		{
+			Linters:  []string{"errcheck"},
+			Function: `^(removeAll|cleaner\.clean)$`,
+		},
+		{
+			Linters: []string{"golint"},
+			Package: `^github\.com/org/repo/legacy(/|$)`,
+		},
+		{
+			Linters:     []string{"unused"},
+			GeneratedBy: "protoc-gen-go",
+		},
+		{
+			Linters: []string{"gocritic"},
+			Package: `^github\.com/org/repo/legacy(_test)?$`,
+		},
+		{
+			Linters:     []string{"deadcode"},
+			GeneratedBy: "^$",
+		},
+	}, nil, astCache, generated, logutils.NewStderrLog(""), nil)
+
+	newIssue := func(path string, line int, linter string) result.Issue {
+		return result.Issue{
+			Pos:        token.Position{Filename: path, Line: line},
+			FromLinter: linter,
+		}
+	}
+	issues := []result.Issue{
+		newIssue(funcsPath, 5, "errcheck"),   // excluded: doc of removeAll
+		newIssue(funcsPath, 7, "errcheck"),   // excluded: in removeAll
+		newIssue(funcsPath, 13, "errcheck"),  // excluded: in cleaner.clean
+		newIssue(funcsPath, 16, "errcheck"),  // not in a function
+		newIssue(funcsPath, 16, "golint"),    // excluded: package legacy/sub
+		newIssue(generatedPath, 5, "golint"), // other package
+		newIssue(generatedPath, 5, "unused"), // excluded: generated by protoc-gen-go
+		newIssue(funcsPath, 7, "unused"),     // not generated
+		newIssue(mockPath, 3, "golint"),      // legacy_test isn't a subpackage of legacy
+		newIssue(mockPath, 3, "gocritic"),    // excluded: external tests of legacy
+		newIssue(mockPath, 3, "unused"),      // generated by the name without the header
+		newIssue(mockPath, 3, "deadcode"),    // excluded: generated without the header
+		newIssue(funcsPath, 7, "deadcode"),   // not generated
+	}
+
+	processedIssues := process(t, p, issues...)
+	assert.Equal(t, []result.Issue{issues[3], issues[5], issues[7], issues[8], issues[10], issues[12]}, processedIssues)
 }
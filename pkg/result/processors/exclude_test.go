@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -51,3 +52,14 @@ func TestExclude(t *testing.T) {
 func TestNoExclude(t *testing.T) {
 	processAssertSame(t, NewExclude(""), newTextIssue("test"))
 }
+
+func TestExcludePatternsUnused(t *testing.T) {
+	log := logutils.NewMockLog()
+	log.On("Warnf", "%s didn't exclude any issue, remove it from the config", `Exclude pattern "^fixed$"`)
+
+	p := NewExcludePatterns([]string{"^exclude$", "^fixed$"}, []string{"^default$"}, &UnusedExcludes{Log: log})
+	processAssertEmpty(t, p, newTextIssue("exclude"))
+
+	p.Finish()
+	log.AssertExpectations(t)
+}
@@ -64,29 +64,41 @@ func (p FilenameUnadjuster) Name() string {
 
 func (p *FilenameUnadjuster) Process(issues []result.Issue) ([]result.Issue, error) {
 	return transformIssues(issues, func(i *result.Issue) *result.Issue {
-		issueFilePath := i.FilePath()
-		if !filepath.IsAbs(i.FilePath()) {
-			absPath, err := filepath.Abs(i.FilePath())
-			if err != nil {
-				p.log.Warnf("failed to build abs path for %q: %s", i.FilePath(), err)
-				return i
+		newI := *i
+		newI.Pos = p.unadjustPos(i.Pos)
+		if len(i.Related) != 0 {
+			newI.Related = make([]result.RelatedLocation, 0, len(i.Related))
+			for _, r := range i.Related {
+				r.Pos = p.unadjustPos(r.Pos)
+				newI.Related = append(newI.Related, r)
 			}
-			issueFilePath = absPath
 		}
+		return &newI
+	}), nil
+}
 
-		mapper := p.m[issueFilePath]
-		if mapper == nil {
-			return i
+func (p *FilenameUnadjuster) unadjustPos(pos token.Position) token.Position {
+	filePath := pos.Filename
+	if !filepath.IsAbs(filePath) {
+		absPath, err := filepath.Abs(filePath)
+		if err != nil {
+			p.log.Warnf("failed to build abs path for %q: %s", filePath, err)
+			return pos
 		}
+		filePath = absPath
+	}
 
-		newI := *i
-		newI.Pos = mapper(i.Pos)
-		if !p.loggedUnadjustments[i.Pos.Filename] {
-			p.log.Infof("Unadjusted from %v to %v", i.Pos, newI.Pos)
-			p.loggedUnadjustments[i.Pos.Filename] = true
-		}
-		return &newI
-	}), nil
+	mapper := p.m[filePath]
+	if mapper == nil {
+		return pos
+	}
+
+	newPos := mapper(pos)
+	if !p.loggedUnadjustments[pos.Filename] {
+		p.log.Infof("Unadjusted from %v to %v", pos, newPos)
+		p.loggedUnadjustments[pos.Filename] = true
+	}
+	return newPos
 }
 
 func (FilenameUnadjuster) Finish() {}
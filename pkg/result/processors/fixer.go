@@ -50,7 +50,7 @@ func (f Fixer) Process(issues <-chan result.Issue) <-chan result.Issue {
 	go func() {
 		issuesToFixPerFile := map[string][]result.Issue{}
 		for issue := range issues {
-			if issue.Replacement == nil {
+			if issue.Replacement == nil || issue.Suppression != nil {
 				outCh <- issue
 				continue
 			}
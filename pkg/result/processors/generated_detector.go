@@ -0,0 +1,92 @@
+package processors
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+)
+
+// strictGeneratedCommentRe is a comment marking generated files by https://golang.org/s/generatedcode.
+var strictGeneratedCommentRe = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// GeneratedDetector finds generated files by the configured mode, headers and
+// globs of file names.
+type GeneratedDetector struct {
+	mode    string
+	headers []*regexp.Regexp
+	files   []string
+}
+
+// NewGeneratedDetector expects the validated config.
+func NewGeneratedDetector(cfg *config.Generated) *GeneratedDetector {
+	d := &GeneratedDetector{
+		mode:  cfg.Mode,
+		files: cfg.Files,
+	}
+	if d.mode == "" {
+		d.mode = config.GeneratedModeLax
+	}
+	for _, header := range cfg.Headers {
+		d.headers = append(d.headers, regexp.MustCompile(header))
+	}
+	return d
+}
+
+func (d GeneratedDetector) IsDisabled() bool {
+	return d.mode == config.GeneratedModeDisabled
+}
+
+// Detect returns why the file is generated or an empty string if it isn't.
+func (d GeneratedDetector) Detect(filePath string, f *ast.File, fset *token.FileSet) string {
+	if d.IsDisabled() {
+		return ""
+	}
+
+	fileName := filepath.Base(filePath)
+	for _, glob := range d.files {
+		if matched, _ := filepath.Match(glob, fileName); matched {
+			return fmt.Sprintf("its name matches %q", glob)
+		}
+	}
+
+	doc := getDoc(f, fset, filePath)
+	for _, header := range d.headers {
+		if header.MatchString(doc) {
+			return fmt.Sprintf("its header comments match %q", header)
+		}
+	}
+
+	if d.mode == config.GeneratedModeStrict {
+		if comment := getStrictGeneratedComment(f); comment != "" {
+			return fmt.Sprintf("it has the comment %q", comment)
+		}
+		return ""
+	}
+
+	if marker := getGeneratedMarker(doc); marker != "" {
+		return fmt.Sprintf("its header comments contain %q", marker)
+	}
+	return ""
+}
+
+// getStrictGeneratedComment returns a comment marking the file as generated
+// before the package clause or an empty string.
+func getStrictGeneratedComment(f *ast.File) string {
+	for _, g := range f.Comments {
+		if g.Pos() > f.Package {
+			break
+		}
+		for _, c := range g.List {
+			// see getDoc about comments implicitly added by cgo
+			if strictGeneratedCommentRe.MatchString(c.Text) && !strings.Contains(c.Text, "Code generated by cmd/cgo") {
+				return c.Text
+			}
+		}
+	}
+	return ""
+}
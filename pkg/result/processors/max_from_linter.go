@@ -1,6 +1,8 @@
 package processors
 
 import (
+	"fmt"
+
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -44,6 +46,10 @@ func (p *MaxFromLinter) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
+func (p MaxFromLinter) Explain(i *result.Issue) string {
+	return fmt.Sprintf("only %d issues from %s are reported, use --max-issues-per-linter", p.limit, i.FromLinter)
+}
+
 func (p MaxFromLinter) Finish() {
 	walkStringToIntMapSortedByValue(p.lc, func(linter string, count int) {
 		if count > p.limit {
@@ -1,6 +1,8 @@
 package processors
 
 import (
+	"fmt"
+
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
@@ -57,4 +59,9 @@ func (p *MaxPerFileFromLinter) Process(issues []result.Issue) ([]result.Issue, e
 	}), nil
 }
 
+func (p MaxPerFileFromLinter) Explain(i *result.Issue) string {
+	return fmt.Sprintf("only %d issues from %s are reported per file",
+		p.maxPerFileFromLinterConfig[i.FromLinter], i.FromLinter)
+}
+
 func (p MaxPerFileFromLinter) Finish() {}
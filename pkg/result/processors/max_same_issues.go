@@ -1,6 +1,7 @@
 package processors
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/golangci/golangci-lint/pkg/config"
@@ -49,6 +50,10 @@ func (p *MaxSameIssues) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
+func (p MaxSameIssues) Explain(i *result.Issue) string {
+	return fmt.Sprintf("only %d issues with the same text are reported, use --max-same-issues", p.limit)
+}
+
 func (p MaxSameIssues) Finish() {
 	walkStringToIntMapSortedByValue(p.tc, func(text string, count int) {
 		if count > p.limit {
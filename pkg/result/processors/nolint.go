@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -17,10 +20,18 @@ import (
 
 var nolintDebugf = logutils.Debug("nolint")
 
+// linterRule is a rule of a linter set in nolint directive as linter/RULE.
+type linterRule struct {
+	linter string
+	ruleID string
+}
+
 type ignoredRange struct {
 	linters []string
+	rules   []linterRule
 	result.Range
-	col int
+	col       int
+	directive string // a comment with the nolint directive
 }
 
 func (i *ignoredRange) doesMatch(issue *result.Issue) bool {
@@ -28,7 +39,7 @@ func (i *ignoredRange) doesMatch(issue *result.Issue) bool {
 		return false
 	}
 
-	if len(i.linters) == 0 {
+	if len(i.linters) == 0 && len(i.rules) == 0 {
 		return true
 	}
 
@@ -38,6 +49,12 @@ func (i *ignoredRange) doesMatch(issue *result.Issue) bool {
 		}
 	}
 
+	for _, r := range i.rules {
+		if r.linter == issue.FromLinter && r.ruleID == issue.RuleID {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -54,6 +71,8 @@ type Nolint struct {
 	log       logutils.Log
 
 	unknownLintersSet map[string]bool
+	unknownRulesSet   map[string]bool
+	ruleIDsCache      map[string][]string
 }
 
 func NewNolint(astCache *astcache.Cache, log logutils.Log, dbManager *lintersdb.Manager) *Nolint {
@@ -63,6 +82,8 @@ func NewNolint(astCache *astcache.Cache, log logutils.Log, dbManager *lintersdb.
 		dbManager:         dbManager,
 		log:               log,
 		unknownLintersSet: map[string]bool{},
+		unknownRulesSet:   map[string]bool{},
+		ruleIDsCache:      map[string][]string{},
 	}
 }
 
@@ -126,6 +147,11 @@ func (p *Nolint) buildIgnoredRangesForFile(f *ast.File, fset *token.FileSet, fil
 }
 
 func (p *Nolint) shouldPassIssue(i *result.Issue) (bool, error) {
+	if filepath.Ext(i.FilePath()) != ".go" {
+		// e.g. an issue about an expired exclude rule in the config file
+		return true, nil
+	}
+
 	fd, err := p.getOrCreateFileData(i)
 	if err != nil {
 		return false, err
@@ -140,6 +166,20 @@ func (p *Nolint) shouldPassIssue(i *result.Issue) (bool, error) {
 	return true, nil
 }
 
+func (p *Nolint) Explain(i *result.Issue) string {
+	fd := p.cache[i.FilePath()]
+	if fd == nil {
+		return ""
+	}
+
+	for _, ir := range fd.ignoredRanges {
+		if ir.doesMatch(i) {
+			return fmt.Sprintf("%s excludes lines %d-%d", ir.directive, ir.From, ir.To)
+		}
+	}
+	return ""
+}
+
 type rangeExpander struct {
 	fset           *token.FileSet
 	inlineRanges   []ignoredRange
@@ -193,70 +233,152 @@ func (p *Nolint) extractFileCommentsInlineRanges(fset *token.FileSet, comments .
 }
 
 func (p *Nolint) extractInlineRangeFromComment(text string, g ast.Node, fset *token.FileSet) *ignoredRange {
-	text = strings.TrimLeft(text, "/ ")
-	if !strings.HasPrefix(text, "nolint") {
+	directive := text
+	if !isNolintDirective(text) {
 		return nil
 	}
+	if parseNolintExpiry(directive).IsExpired(time.Now()) {
+		return nil // expired directives are reported as issues
+	}
+	text = strings.TrimLeft(text, "/ ")
 
-	buildRange := func(linters []string) *ignoredRange {
+	buildRange := func(linters []string, rules []linterRule) *ignoredRange {
 		pos := fset.Position(g.Pos())
 		return &ignoredRange{
 			Range: result.Range{
 				From: pos.Line,
 				To:   fset.Position(g.End()).Line,
 			},
-			col:     pos.Column,
-			linters: linters,
+			col:       pos.Column,
+			linters:   linters,
+			rules:     rules,
+			directive: directive,
 		}
 	}
 
 	if !strings.HasPrefix(text, "nolint:") {
-		return buildRange(nil) // ignore all linters
+		return buildRange(nil, nil) // ignore all linters
 	}
 
-	// ignore specific linters
+	// ignore specific linters or rules of linters
 	var linters []string
+	var rules []linterRule
 	text = strings.Split(text, "//")[0] // allow another comment after this comment
-	linterItems := strings.Split(strings.TrimPrefix(text, "nolint:"), ",")
+	items := strings.Split(strings.TrimPrefix(text, "nolint:"), ",")
 	var gotUnknownLinters bool
-	for _, linter := range linterItems {
-		linterName := strings.ToLower(strings.TrimSpace(linter))
-		metaLinter := p.dbManager.GetMetaLinter(linterName)
-		if metaLinter != nil {
-			// user can set metalinter name in nolint directive (e.g. megacheck), then
-			// we should add to nolint all the metalinter's default children
-			linters = append(linters, metaLinter.DefaultChildLinterNames()...)
+	for _, item := range items {
+		linterName, ruleID := parseNolintItem(item)
+		linterNames := p.resolveLinterNames(linterName)
+		if linterNames == nil {
+			p.unknownLintersSet[linterName] = true
+			gotUnknownLinters = true
 			continue
 		}
 
-		lc := p.dbManager.GetLinterConfig(linterName)
-		if lc == nil {
-			p.unknownLintersSet[linterName] = true
-			gotUnknownLinters = true
+		if ruleID == "" {
+			linters = append(linters, linterNames...)
 			continue
 		}
 
-		linters = append(linters, lc.Name()) // normalize name to work with aliases
+		itemRules := p.resolveRules(linterNames, ruleID)
+		if itemRules == nil {
+			p.unknownRulesSet[linterName+"/"+ruleID] = true
+			continue // a typo in a rule ID must not hide other issues of the linter
+		}
+		rules = append(rules, itemRules...)
 	}
 
 	if gotUnknownLinters {
-		return buildRange(nil) // ignore all linters to not annoy user
+		return buildRange(nil, nil) // ignore all linters to not annoy user
+	}
+	if len(linters) == 0 && len(rules) == 0 {
+		return nil // all rules are unknown: an empty range would ignore all linters
 	}
 
-	nolintDebugf("%d: linters are %s", fset.Position(g.Pos()).Line, linters)
-	return buildRange(linters)
+	nolintDebugf("%d: linters are %s, rules are %v", fset.Position(g.Pos()).Line, linters, rules)
+	return buildRange(linters, rules)
 }
 
-func (p Nolint) Finish() {
-	if len(p.unknownLintersSet) == 0 {
-		return
+// parseNolintItem parses an item of nolint directive: linter or linter/RULE.
+func parseNolintItem(item string) (linterName, ruleID string) {
+	item = strings.TrimSpace(item)
+	if slash := strings.Index(item, "/"); slash != -1 {
+		return strings.ToLower(strings.TrimSpace(item[:slash])), strings.TrimSpace(item[slash+1:])
 	}
+	return strings.ToLower(item), ""
+}
 
-	unknownLinters := []string{}
-	for name := range p.unknownLintersSet {
-		unknownLinters = append(unknownLinters, name)
+// resolveLinterNames returns nil for unknown linters.
+func (p *Nolint) resolveLinterNames(linterName string) []string {
+	metaLinter := p.dbManager.GetMetaLinter(linterName)
+	if metaLinter != nil {
+		// user can set metalinter name in nolint directive (e.g. megacheck), then
+		// we should add to nolint all the metalinter's default children
+		return metaLinter.DefaultChildLinterNames()
 	}
-	sort.Strings(unknownLinters)
 
-	p.log.Warnf("Found unknown linters in //nolint directives: %s", strings.Join(unknownLinters, ", "))
+	lc := p.dbManager.GetLinterConfig(linterName)
+	if lc == nil {
+		return nil
+	}
+
+	return []string{lc.Name()} // normalize name to work with aliases
+}
+
+// resolveRules returns rules of linters having the rule ID, it returns nil if no
+// linter has it. Rule IDs are case insensitive, e.g. gosec/g104 means gosec/G104.
+// Rule IDs of linters which can't list them aren't validated.
+func (p *Nolint) resolveRules(linterNames []string, ruleID string) []linterRule {
+	var ret []linterRule
+	for _, name := range linterNames {
+		ruleIDs, ok := p.getRuleIDs(name)
+		if !ok {
+			ret = append(ret, linterRule{linter: name, ruleID: ruleID})
+			continue
+		}
+
+		for _, id := range ruleIDs {
+			if strings.EqualFold(id, ruleID) {
+				ret = append(ret, linterRule{linter: name, ruleID: id})
+				break
+			}
+		}
+	}
+
+	return ret
+}
+
+func (p *Nolint) getRuleIDs(linterName string) ([]string, bool) {
+	if ruleIDs, ok := p.ruleIDsCache[linterName]; ok {
+		return ruleIDs, ruleIDs != nil
+	}
+
+	var ruleIDs []string
+	if provider, ok := p.dbManager.GetLinterConfig(linterName).Linter.(linter.RuleIDsProvider); ok {
+		ruleIDs = provider.RuleIDs()
+		if ruleIDs == nil {
+			ruleIDs = []string{}
+		}
+	}
+	p.ruleIDsCache[linterName] = ruleIDs
+	return ruleIDs, ruleIDs != nil
+}
+
+func (p Nolint) Finish() {
+	if len(p.unknownLintersSet) != 0 {
+		p.log.Warnf("Found unknown linters in //nolint directives: %s", joinSortedKeys(p.unknownLintersSet))
+	}
+	if len(p.unknownRulesSet) != 0 {
+		p.log.Warnf("Found unknown rules in //nolint directives, they don't exclude anything: %s",
+			joinSortedKeys(p.unknownRulesSet))
+	}
+}
+
+func joinSortedKeys(set map[string]bool) string {
+	keys := []string{}
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
 }
@@ -2,9 +2,11 @@ package processors
 
 import (
 	"fmt"
+	"go/parser"
 	"go/token"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -37,6 +39,8 @@ func newTestNolintProcessor(log logutils.Log) *Nolint {
 		filepath.Join("testdata", "nolint2.go"),
 		filepath.Join("testdata", "nolint_bad_names.go"),
 		filepath.Join("testdata", "nolint_whole_file.go"),
+		filepath.Join("testdata", "nolint_rules.go"),
+		filepath.Join("testdata", "nolint_expires.go"),
 	)
 	return NewNolint(cache, log, lintersdb.NewManager(nil))
 }
@@ -166,6 +170,96 @@ func TestNolintAliases(t *testing.T) {
 	p.Finish()
 }
 
+func TestNolintRules(t *testing.T) {
+	newIssue := func(line int, fromLinter, ruleID string) result.Issue {
+		return result.Issue{
+			Pos: token.Position{
+				Filename: filepath.Join("testdata", "nolint_rules.go"),
+				Line:     line,
+			},
+			FromLinter: fromLinter,
+			RuleID:     ruleID,
+		}
+	}
+
+	log := getMockLog()
+	log.On("Warnf", "Found unknown rules in //nolint directives, they don't exclude anything: %s", "gosec/G999")
+
+	p := newTestNolintProcessor(log)
+	processAssertEmpty(t, p, newIssue(3, "gosec", "G104"))
+	processAssertSame(t, p, newIssue(3, "gosec", "G304"))
+	processAssertEmpty(t, p, newIssue(3, "staticcheck", "SA1019")) // rule IDs are case insensitive
+	processAssertSame(t, p, newIssue(3, "staticcheck", "SA4006"))
+
+	processAssertEmpty(t, p, newIssue(5, "staticcheck", "SA4006"))
+	processAssertSame(t, p, newIssue(5, "gosimple", "S1000"))
+
+	// unknown rules don't exclude anything
+	processAssertSame(t, p, newIssue(7, "gosec", "G304"))
+	processAssertSame(t, p, newIssue(7, "gosec", "G999"))
+	processAssertSame(t, p, newIssue(7, "govet", "printf"))
+	p.Finish()
+}
+
+func TestNolintExpires(t *testing.T) {
+	newIssue := func(line int) result.Issue {
+		return result.Issue{
+			Pos: token.Position{
+				Filename: filepath.Join("testdata", "nolint_expires.go"),
+				Line:     line,
+			},
+			FromLinter: "gosec",
+		}
+	}
+
+	p := newTestNolintProcessor(getMockLog())
+	processAssertSame(t, p, newIssue(3))
+	processAssertEmpty(t, p, newIssue(5))
+	processAssertEmpty(t, p, newIssue(7))
+	p.Finish()
+}
+
+func TestFindNolintDirectives(t *testing.T) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filepath.Join("testdata", "nolint_expires.go"), nil, parser.ParseComments)
+	assert.NoError(t, err)
+
+	directives := FindNolintDirectives(f, fset)
+	assert.Len(t, directives, 3)
+
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.Local)
+	var got []SuppressionExpiry
+	for _, d := range directives {
+		got = append(got, d.SuppressionExpiry)
+	}
+	assert.Equal(t, []SuppressionExpiry{
+		{Expires: "2000-01-01", Owner: "alice"},
+		{Expires: "2999-12-31", Owner: "bob"},
+		{Owner: "carol"},
+	}, got)
+	assert.Equal(t, []string{"gosec"}, directives[1].Linters)
+	assert.Equal(t, "the reason", directives[1].Reason)
+	assert.True(t, got[0].IsExpired(now))
+	assert.False(t, got[1].IsExpired(now))
+	assert.False(t, got[2].IsExpired(now))
+
+	// a suppression applies through the day of expiry
+	assert.False(t, SuppressionExpiry{Expires: "2019-10-01"}.IsExpired(now.Add(23*time.Hour)))
+	assert.True(t, SuppressionExpiry{Expires: "2019-10-01"}.IsExpired(now.Add(24*time.Hour)))
+}
+
+func TestParseNolintDirective(t *testing.T) {
+	linters, ruleIDs, reason := parseNolintDirective("//nolint:gosec/G304, GoLint,gosec/G104 // legacy code // expires:2020-01-01")
+	assert.Equal(t, []string{"gosec", "golint"}, linters)
+	assert.Equal(t, []string{"gosec/G304", "gosec/G104"}, ruleIDs)
+	assert.Equal(t, "legacy code", reason)
+
+	linters, ruleIDs, reason = parseNolintDirective("// nolint")
+	assert.Empty(t, linters)
+	assert.Empty(t, ruleIDs)
+	assert.Empty(t, reason)
+}
+
 func TestIgnoredRangeMatches(t *testing.T) {
 	var testcases = []struct {
 		doc      string
@@ -30,19 +30,30 @@ func (p PathPrettifier) Name() string {
 
 func (p PathPrettifier) Process(issues []result.Issue) ([]result.Issue, error) {
 	return transformIssues(issues, func(i *result.Issue) *result.Issue {
-		if !filepath.IsAbs(i.FilePath()) {
-			return i
+		newI := *i
+		newI.Pos.Filename = prettifyPath(i.FilePath())
+		if len(i.Related) != 0 {
+			newI.Related = make([]result.RelatedLocation, 0, len(i.Related))
+			for _, r := range i.Related {
+				r.Pos.Filename = prettifyPath(r.Pos.Filename)
+				newI.Related = append(newI.Related, r)
+			}
 		}
+		return &newI
+	}), nil
+}
 
-		rel, err := fsutils.ShortestRelPath(i.FilePath(), "")
-		if err != nil {
-			return i
-		}
+func prettifyPath(path string) string {
+	if !filepath.IsAbs(path) {
+		return path
+	}
 
-		newI := i
-		newI.Pos.Filename = rel
-		return newI
-	}), nil
+	rel, err := fsutils.ShortestRelPath(path, "")
+	if err != nil {
+		return path
+	}
+
+	return rel
 }
 
 func (p PathPrettifier) Finish() {}
@@ -0,0 +1,38 @@
+package processors
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestPathPrettifierRelated(t *testing.T) {
+	wd, err := fsutils.Getwd()
+	require.NoError(t, err)
+
+	issue := result.Issue{
+		Pos: token.Position{Filename: filepath.Join(wd, "testdata", "nolint.go"), Line: 1},
+		Related: []result.RelatedLocation{
+			{Pos: token.Position{Filename: filepath.Join(wd, "testdata", "nolint2.go"), Line: 2}, Message: "related"},
+			{Pos: token.Position{Filename: "c.go", Line: 3}, Message: "relative"},
+		},
+	}
+
+	processedIssues, err := NewPathPrettifier().Process([]result.Issue{issue})
+	require.NoError(t, err)
+	require.Len(t, processedIssues, 1)
+
+	i := processedIssues[0]
+	assert.Equal(t, filepath.Join("testdata", "nolint.go"), i.FilePath())
+	assert.Equal(t, []result.RelatedLocation{
+		{Pos: token.Position{Filename: filepath.Join("testdata", "nolint2.go"), Line: 2}, Message: "related"},
+		{Pos: token.Position{Filename: "c.go", Line: 3}, Message: "relative"},
+	}, i.Related)
+	assert.Equal(t, filepath.Join(wd, "testdata", "nolint2.go"), issue.Related[0].Pos.Filename, "source issue is modified")
+}
@@ -7,3 +7,10 @@ type Processor interface {
 	Name() string
 	Finish()
 }
+
+// Explainer is implemented by processors which can tell why they have
+// filtered out an issue: it's used by --explain-location.
+type Explainer interface {
+	// Explain returns a reason of filtering out of the issue.
+	Explain(i *result.Issue) string
+}
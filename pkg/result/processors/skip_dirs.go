@@ -1,6 +1,7 @@
 package processors
 
 import (
+	"fmt"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -135,6 +136,14 @@ func (p *SkipDirs) shouldPassIssueDirs(issueRelDir, issueAbsDir string) bool {
 	return true
 }
 
+func (p *SkipDirs) Explain(i *result.Issue) string {
+	issueRelDir := filepath.Dir(i.FilePath())
+	if stat := p.skippedDirs[issueRelDir]; stat != nil {
+		return fmt.Sprintf("dir %s matches skip-dirs pattern %q", issueRelDir, stat.pattern)
+	}
+	return ""
+}
+
 func (p *SkipDirs) Finish() {
 	for dir, stat := range p.skippedDirs {
 		p.log.Infof("Skipped %d issues from dir %s by pattern %s", stat.count, dir, stat.pattern)
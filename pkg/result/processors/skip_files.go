@@ -48,4 +48,13 @@ func (p SkipFiles) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
+func (p SkipFiles) Explain(i *result.Issue) string {
+	for _, p := range p.patterns {
+		if p.MatchString(i.FilePath()) {
+			return fmt.Sprintf("path matches skip-files pattern %q", p)
+		}
+	}
+	return ""
+}
+
 func (p SkipFiles) Finish() {}
@@ -0,0 +1,113 @@
+package processors
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+)
+
+// ExpiredSuppressionsLinterName is a name of a pseudo linter reporting
+// expired nolint directives and exclude rules: they don't apply anymore.
+const ExpiredSuppressionsLinterName = "suppressions"
+
+// SuppressionExpiry is set for exclude rules by expires and owner options and
+// for nolint directives by expires:YYYY-MM-DD and owner:NAME in comments after
+// the directive, e.g. `//nolint:gosec // expires:2026-12-31 owner:alice`.
+type SuppressionExpiry struct {
+	Expires string // in config.ExpiresLayout, empty if the suppression doesn't expire
+	Owner   string
+}
+
+// IsExpired returns true if the suppression doesn't apply at now: a suppression
+// applies through the whole day of expiry.
+func (e SuppressionExpiry) IsExpired(now time.Time) bool {
+	return e.Expires != "" && now.Format(config.ExpiresLayout) > e.Expires
+}
+
+var (
+	nolintExpiresRe = regexp.MustCompile(`\bexpires:\s*(\d{4}-\d{2}-\d{2})\b`)
+	nolintOwnerRe   = regexp.MustCompile(`\bowner:\s*(\S+)`)
+)
+
+func parseNolintExpiry(directive string) SuppressionExpiry {
+	var ret SuppressionExpiry
+	if m := nolintExpiresRe.FindStringSubmatch(directive); m != nil {
+		ret.Expires = m[1]
+	}
+	if m := nolintOwnerRe.FindStringSubmatch(directive); m != nil {
+		ret.Owner = m[1]
+	}
+	return ret
+}
+
+func isNolintDirective(comment string) bool {
+	return strings.HasPrefix(strings.TrimLeft(comment, "/ "), "nolint")
+}
+
+// NolintDirective is a //nolint comment in a file.
+type NolintDirective struct {
+	Pos     token.Position
+	Text    string
+	Linters []string // linters as they're written in the directive, empty for all linters
+	RuleIDs []string // linter/RULE items of the directive
+	Reason  string   // a comment after the directive without expiry options
+	SuppressionExpiry
+}
+
+func parseNolintDirective(comment string) (linters, ruleIDs []string, reason string) {
+	text := strings.TrimLeft(comment, "/ ")
+	if slashes := strings.Index(text, "//"); slashes != -1 {
+		reason = text[slashes+2:]
+		text = text[:slashes]
+	}
+	reason = nolintExpiresRe.ReplaceAllString(reason, "")
+	reason = nolintOwnerRe.ReplaceAllString(reason, "")
+	reason = strings.Join(strings.Fields(strings.Replace(reason, "//", " ", -1)), " ")
+
+	if !strings.HasPrefix(text, "nolint:") {
+		return nil, nil, reason
+	}
+
+	seenLinters := map[string]bool{}
+	for _, item := range strings.Split(strings.TrimPrefix(text, "nolint:"), ",") {
+		linterName, ruleID := parseNolintItem(item)
+		if linterName == "" {
+			continue
+		}
+		if ruleID != "" {
+			ruleIDs = append(ruleIDs, linterName+"/"+ruleID)
+		}
+		if !seenLinters[linterName] {
+			seenLinters[linterName] = true
+			linters = append(linters, linterName)
+		}
+	}
+	return linters, ruleIDs, reason
+}
+
+// FindNolintDirectives returns all nolint directives in comments of the file.
+func FindNolintDirectives(f *ast.File, fset *token.FileSet) []NolintDirective {
+	var ret []NolintDirective
+	for _, g := range f.Comments {
+		for _, c := range g.List {
+			if !isNolintDirective(c.Text) {
+				continue
+			}
+
+			linters, ruleIDs, reason := parseNolintDirective(c.Text)
+			ret = append(ret, NolintDirective{
+				Pos:               fset.Position(c.Pos()),
+				Text:              c.Text,
+				Linters:           linters,
+				RuleIDs:           ruleIDs,
+				Reason:            reason,
+				SuppressionExpiry: parseNolintExpiry(c.Text),
+			})
+		}
+	}
+	return ret
+}
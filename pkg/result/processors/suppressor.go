@@ -0,0 +1,80 @@
+package processors
+
+import (
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Suppressor sets Suppression of issues filtered out by the processor
+// instead of removing them: it's used by --show-suppressed.
+type Suppressor struct {
+	p    Processor
+	kind string
+}
+
+var _ Processor = &Suppressor{}
+
+func NewSuppressor(p Processor, kind string) *Suppressor {
+	return &Suppressor{
+		p:    p,
+		kind: kind,
+	}
+}
+
+func (s Suppressor) Name() string {
+	return s.p.Name()
+}
+
+func (s *Suppressor) Process(issues []result.Issue) ([]result.Issue, error) {
+	var activeIssues []result.Issue
+	for _, i := range issues {
+		if i.Suppression == nil {
+			activeIssues = append(activeIssues, i)
+		}
+	}
+
+	passedIssues, err := s.p.Process(activeIssues)
+	if err != nil {
+		return nil, err
+	}
+
+	// processors keep order of issues
+	retIssues := make([]result.Issue, 0, len(issues))
+	passedIdx := 0
+	for _, i := range issues {
+		i := i
+		if i.Suppression != nil {
+			retIssues = append(retIssues, i)
+			continue
+		}
+
+		if passedIdx < len(passedIssues) && isSameIssue(&passedIssues[passedIdx], &i) {
+			retIssues = append(retIssues, passedIssues[passedIdx])
+			passedIdx++
+			continue
+		}
+
+		i.Suppression = &result.Suppression{
+			Kind:   s.kind,
+			Source: s.Explain(&i),
+		}
+		retIssues = append(retIssues, i)
+	}
+
+	return retIssues, nil
+}
+
+func (s Suppressor) Explain(i *result.Issue) string {
+	if explainer, ok := s.p.(Explainer); ok {
+		return explainer.Explain(i)
+	}
+	return ""
+}
+
+func (s Suppressor) Finish() {
+	s.p.Finish()
+}
+
+// isSameIssue doesn't compare fields set by processors, e.g. HunkPos.
+func isSameIssue(a, b *result.Issue) bool {
+	return a.FromLinter == b.FromLinter && a.RuleID == b.RuleID && a.Text == b.Text && a.Pos == b.Pos
+}
@@ -0,0 +1,38 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestSuppressor(t *testing.T) {
+	p := NewSuppressor(NewExcludePatterns([]string{"^exclude$", "^other$"}, nil, nil), "exclude")
+	issues := []result.Issue{newTextIssue("1"), newTextIssue("exclude"), newTextIssue("2")}
+
+	processedIssues := process(t, p, issues...)
+	assert.Len(t, processedIssues, len(issues))
+	assert.Nil(t, processedIssues[0].Suppression)
+	assert.Nil(t, processedIssues[2].Suppression)
+	if assert.NotNil(t, processedIssues[1].Suppression) {
+		assert.Equal(t, "exclude", processedIssues[1].Suppression.Kind)
+		assert.Contains(t, processedIssues[1].Suppression.Source, "^exclude$")
+	}
+
+	// already suppressed issues aren't suppressed again
+	p = NewSuppressor(NewExclude("^2$"), "exclude")
+	processedIssues = process(t, p, processedIssues...)
+	assert.Equal(t, "exclude", processedIssues[1].Suppression.Kind)
+	assert.Contains(t, processedIssues[1].Suppression.Source, "^exclude$")
+	assert.NotNil(t, processedIssues[2].Suppression)
+	assert.Nil(t, processedIssues[0].Suppression)
+}
+
+func TestFilterIssuesKeepsSuppressed(t *testing.T) {
+	issues := []result.Issue{newTextIssue("exclude")}
+	issues[0].Suppression = &result.Suppression{Kind: "nolint"}
+
+	processAssertSame(t, NewExclude("^exclude$"), issues...)
+}
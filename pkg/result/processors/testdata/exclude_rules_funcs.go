@@ -0,0 +1,16 @@
+package testdata
+
+import "os"
+
+// removeAll ignores errors of removing
+func removeAll(path string) {
+	os.RemoveAll(path)
+}
+
+type cleaner struct{}
+
+func (c *cleaner) clean(path string) {
+	os.Remove(path)
+}
+
+var removeErr = os.Remove("a")
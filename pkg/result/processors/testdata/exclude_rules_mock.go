@@ -0,0 +1,3 @@
+package testdata_test
+
+var mockVar int
@@ -0,0 +1,7 @@
+package testdata
+
+var nolintExpired bool //nolint:gosec // expires:2000-01-01 owner:alice
+
+var nolintNotExpired bool //nolint:gosec // expires:2999-12-31 owner:bob the reason
+
+var nolintNotExpiring bool //nolint:gosec // owner:carol
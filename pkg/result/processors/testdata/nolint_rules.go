@@ -0,0 +1,7 @@
+package testdata
+
+var nolintRules bool //nolint:gosec/G104,staticcheck/sa1019
+
+var nolintMetalinterRule bool //nolint:megacheck/SA4006
+
+var nolintUnknownRule bool //nolint:gosec/G999
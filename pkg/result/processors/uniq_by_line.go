@@ -34,6 +34,11 @@ func (p *UniqByLine) Process(issues []result.Issue) ([]result.Issue, error) {
 			return true
 		}
 
+		if i.FromLinter == ExpiredSuppressionsLinterName {
+			// the issue is at the line of the expired nolint directive, it shouldn't hide issues at the line
+			return true
+		}
+
 		lc := p.flc[i.FilePath()]
 		if lc == nil {
 			lc = lineToCount{}
@@ -51,4 +56,8 @@ func (p *UniqByLine) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
+func (p UniqByLine) Explain(i *result.Issue) string {
+	return "another issue is already reported at the line"
+}
+
 func (p UniqByLine) Finish() {}
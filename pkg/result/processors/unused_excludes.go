@@ -0,0 +1,52 @@
+package processors
+
+import (
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// UnusedExcludes configures reporting of configured exclude patterns and rules
+// which didn't exclude any issue: they're likely left after fixed issues.
+// It makes sense only when all issues are processed, i.e. not in diff runs.
+type UnusedExcludes struct {
+	Log  logutils.Log
+	Fail bool // log an error to fail the run
+
+	enabledLinters map[string]bool // nil if all linters are treated as enabled
+}
+
+// SetEnabledLinters sets names of linters which can report issues: rules
+// only for other linters can't exclude anything and aren't reported.
+func (u *UnusedExcludes) SetEnabledLinters(names []string) {
+	u.enabledLinters = map[string]bool{
+		ExpiredSuppressionsLinterName: true,
+	}
+	for _, name := range names {
+		u.enabledLinters[name] = true
+	}
+}
+
+func (u *UnusedExcludes) isAnyLinterEnabled(names []string) bool {
+	if u.enabledLinters == nil || len(names) == 0 {
+		return true
+	}
+
+	for _, name := range names {
+		if u.enabledLinters[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *UnusedExcludes) report(kind string, unused []string) {
+	if u == nil || len(unused) == 0 {
+		return
+	}
+
+	for _, what := range unused {
+		u.Log.Warnf("%s didn't exclude any issue, remove it from the config", what)
+	}
+	if u.Fail {
+		u.Log.Errorf("Found %d unused %s in the config", len(unused), kind)
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
+// filterIssues and filterIssuesErr keep suppressed issues: they're
+// already filtered out by a suppressing processor.
 func filterIssues(issues []result.Issue, filter func(i *result.Issue) bool) []result.Issue {
 	retIssues := make([]result.Issue, 0, len(issues))
 	for _, i := range issues {
 		i := i
-		if filter(&i) {
+		if i.Suppression != nil || filter(&i) {
 			retIssues = append(retIssues, i)
 		}
 	}
@@ -22,6 +24,11 @@ func filterIssuesErr(issues []result.Issue, filter func(i *result.Issue) (bool,
 	retIssues := make([]result.Issue, 0, len(issues))
 	for _, i := range issues {
 		i := i
+		if i.Suppression != nil {
+			retIssues = append(retIssues, i)
+			continue
+		}
+
 		ok, err := filter(&i)
 		if err != nil {
 			return nil, fmt.Errorf("can't filter issue %#v: %s", i, err)
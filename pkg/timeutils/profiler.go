@@ -0,0 +1,217 @@
+package timeutils
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Categories of spans recorded by golangci-lint.
+const (
+	ProfileCategoryLoading    = "loading"
+	ProfileCategoryLinters    = "linters"
+	ProfileCategoryAnalyzers  = "analyzers"
+	ProfileCategoryProcessors = "processors"
+)
+
+// Profiler records spans of work and saves them in the Chrome trace event format:
+// the result can be opened in chrome://tracing or https://ui.perfetto.dev.
+// Every category of spans is shown as a separate process, workers doing
+// the work are shown as its threads (lanes) by their ids.
+// Profiler does nothing until it's started.
+type Profiler struct {
+	startedAt time.Time
+	enabled   bool
+
+	mu         sync.Mutex
+	events     []TraceEvent
+	categories map[string]*profilerCategory
+}
+
+type profilerCategory struct {
+	pid int
+}
+
+// TraceEvent is an event of the Chrome trace event format.
+type TraceEvent struct {
+	Name     string            `json:"name"`
+	Category string            `json:"cat,omitempty"`
+	Phase    string            `json:"ph"`
+	TS       float64           `json:"ts"` // microseconds
+	Duration float64           `json:"dur,omitempty"`
+	PID      int               `json:"pid"`
+	TID      int               `json:"tid"`
+	Args     map[string]string `json:"args,omitempty"`
+}
+
+type traceFile struct {
+	TraceEvents     []TraceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{
+		categories: map[string]*profilerCategory{},
+	}
+}
+
+// Start enables recording of spans. It must be called before any span is started.
+func (p *Profiler) Start() {
+	p.startedAt = time.Now()
+	p.enabled = true
+}
+
+func (p *Profiler) Enabled() bool {
+	return p != nil && p.enabled
+}
+
+// Span is a started span of work, it must be finished by End.
+type Span struct {
+	p         *Profiler
+	category  string
+	name      string
+	workerID  int
+	startedAt time.Time
+	args      map[string]string
+}
+
+// StartSpan starts a span in the category done by the worker: the worker id
+// is the thread id of the span, 0 is for work outside of workers. Spans of
+// a category done by one worker must not overlap. Returns nil if the profiler isn't started.
+func (p *Profiler) StartSpan(category, name string, workerID int) *Span {
+	if !p.Enabled() {
+		return nil
+	}
+
+	p.mu.Lock()
+	if p.categories[category] == nil {
+		p.categories[category] = &profilerCategory{pid: len(p.categories) + 1}
+	}
+	p.mu.Unlock()
+
+	return &Span{
+		p:         p,
+		category:  category,
+		name:      name,
+		workerID:  workerID,
+		startedAt: time.Now(),
+	}
+}
+
+// SetArg attaches additional info to the span.
+func (s *Span) SetArg(key, value string) {
+	if s == nil {
+		return
+	}
+
+	if s.args == nil {
+		s.args = map[string]string{}
+	}
+	s.args[key] = value
+}
+
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	endedAt := time.Now()
+	p := s.p
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	c := p.categories[s.category]
+	p.events = append(p.events, TraceEvent{
+		Name:     s.name,
+		Category: s.category,
+		Phase:    "X",
+		TS:       durationToMicroseconds(s.startedAt.Sub(p.startedAt)),
+		Duration: durationToMicroseconds(endedAt.Sub(s.startedAt)),
+		PID:      c.pid,
+		TID:      s.workerID,
+		Args:     s.args,
+	})
+}
+
+// Track runs f inside of a span.
+func (p *Profiler) Track(category, name string, workerID int, f func()) {
+	span := p.StartSpan(category, name, workerID)
+	defer span.End()
+	f()
+}
+
+type workerIDKey struct{}
+
+// WithWorkerID returns a context of work done by the worker: spans of work
+// started with the context, e.g. by linters, are attributed to the worker.
+func WithWorkerID(ctx context.Context, workerID int) context.Context {
+	return context.WithValue(ctx, workerIDKey{}, workerID)
+}
+
+// WorkerID returns the id of the worker of the context or 0.
+func WorkerID(ctx context.Context) int {
+	workerID, _ := ctx.Value(workerIDKey{}).(int)
+	return workerID
+}
+
+func durationToMicroseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
+
+func (p *Profiler) metadataEvents() []TraceEvent {
+	names := make([]string, 0, len(p.categories))
+	for name := range p.categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ret []TraceEvent
+	for _, name := range names {
+		c := p.categories[name]
+		ret = append(ret, TraceEvent{
+			Name:  "process_name",
+			Phase: "M",
+			PID:   c.pid,
+			Args:  map[string]string{"name": name},
+		})
+	}
+
+	return ret
+}
+
+// Write writes all finished spans in the Chrome trace event JSON format.
+func (p *Profiler) Write(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := append(p.metadataEvents(), p.events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].TS < events[j].TS
+	})
+
+	return json.NewEncoder(w).Encode(traceFile{
+		TraceEvents:     events,
+		DisplayTimeUnit: "ms",
+	})
+}
+
+func (p *Profiler) Save(filePath string) error {
+	f, err := os.Create(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "can't create file %s", filePath)
+	}
+
+	if err = p.Write(f); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "can't write profile to %s", filePath)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,71 @@
+package timeutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfilerDisabled(t *testing.T) {
+	p := NewProfiler()
+	span := p.StartSpan(ProfileCategoryLinters, "govet", 1)
+	assert.Nil(t, span)
+	span.SetArg("k", "v")
+	span.End()
+
+	var nilProfiler *Profiler
+	called := false
+	nilProfiler.Track(ProfileCategoryLinters, "govet", 1, func() { called = true })
+	assert.True(t, called)
+}
+
+func TestProfilerWorkers(t *testing.T) {
+	p := NewProfiler()
+	p.Start()
+
+	s1 := p.StartSpan(ProfileCategoryLinters, "govet", 2)
+	s2 := p.StartSpan(ProfileCategoryLinters, "errcheck", 1)
+	s1.End()
+	s3 := p.StartSpan(ProfileCategoryLinters, "golint", 1) // after s2 on the same worker
+	s2.End()
+	s3.SetArg("issues", "3")
+	s3.End()
+	p.Track(ProfileCategoryProcessors, "exclude", 0, func() {})
+
+	var buf bytes.Buffer
+	require.NoError(t, p.Write(&buf))
+
+	var tf traceFile
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &tf))
+	assert.Equal(t, "ms", tf.DisplayTimeUnit)
+
+	spans := map[string]TraceEvent{}
+	processNames := map[int]string{}
+	for _, e := range tf.TraceEvents {
+		switch e.Phase {
+		case "X":
+			spans[e.Name] = e
+		case "M":
+			processNames[e.PID] = e.Args["name"]
+		}
+	}
+
+	require.Len(t, spans, 4)
+	assert.Equal(t, 2, spans["govet"].TID)
+	assert.Equal(t, 1, spans["errcheck"].TID)
+	assert.Equal(t, 1, spans["golint"].TID)
+	assert.Equal(t, 0, spans["exclude"].TID)
+	assert.Equal(t, "3", spans["golint"].Args["issues"])
+	assert.Equal(t, ProfileCategoryLinters, processNames[spans["govet"].PID])
+	assert.Equal(t, ProfileCategoryProcessors, processNames[spans["exclude"].PID])
+}
+
+func TestWorkerID(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, 0, WorkerID(ctx))
+	assert.Equal(t, 3, WorkerID(WithWorkerID(ctx, 3)))
+}